@@ -0,0 +1,70 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// backoff calculates delays between consecutive retries.
+// The delay grows exponentially from InitialDelay by Multiplier up to MaxDelay,
+// and is randomly reduced by up to Jitter fraction of its value.
+type backoff struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	Jitter       float64
+}
+
+// Delay returns the delay before the given retry attempt, starting from 1.
+func (b backoff) Delay(attempt int) time.Duration {
+	if b.InitialDelay <= 0 || attempt < 1 {
+		return 0
+	}
+
+	delay := float64(b.InitialDelay) * math.Pow(math.Max(b.Multiplier, 1), float64(attempt-1))
+
+	if b.MaxDelay > 0 && delay > float64(b.MaxDelay) {
+		delay = float64(b.MaxDelay)
+	}
+
+	if b.Jitter > 0 {
+		delay -= delay * b.Jitter * rand.Float64() //nolint:gosec // jitter does not need a cryptographically secure generator
+	}
+
+	return time.Duration(delay)
+}
+
+// Wait blocks for the delay before the given retry attempt, or until the context is canceled.
+func (b backoff) Wait(ctx context.Context, attempt int) error {
+	delay := b.Delay(attempt)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff_Delay(t *testing.T) {
+	t.Run("Returns no delay when initial delay is not set", func(t *testing.T) {
+		require.Equal(t, time.Duration(0), backoff{}.Delay(3))
+	})
+
+	t.Run("Grows exponentially up to the maximum delay", func(t *testing.T) {
+		b := backoff{
+			InitialDelay: 100 * time.Millisecond,
+			MaxDelay:     time.Second,
+			Multiplier:   3,
+		}
+
+		require.Equal(t, 100*time.Millisecond, b.Delay(1))
+		require.Equal(t, 300*time.Millisecond, b.Delay(2))
+		require.Equal(t, 900*time.Millisecond, b.Delay(3))
+		require.Equal(t, time.Second, b.Delay(4))
+		require.Equal(t, time.Second, b.Delay(10))
+	})
+
+	t.Run("Jitter reduces the delay by up to the given fraction", func(t *testing.T) {
+		b := backoff{
+			InitialDelay: time.Second,
+			Multiplier:   2,
+			Jitter:       0.5,
+		}
+
+		for n := 0; n < 100; n++ {
+			delay := b.Delay(2)
+
+			require.GreaterOrEqual(t, delay, time.Second)
+			require.LessOrEqual(t, delay, 2*time.Second)
+		}
+	})
+}
+
+func TestBackoff_Wait(t *testing.T) {
+	t.Run("Stops waiting when context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		b := backoff{
+			InitialDelay: time.Hour,
+		}
+
+		require.ErrorIs(t, b.Wait(ctx, 1), context.Canceled)
+	})
+}
@@ -24,7 +24,14 @@ type operation struct {
 	CreatedAt time.Time
 	Record    sdk.Record
 	AckFunc   sdk.AckFunc
+	payload   []byte
 	err       error
+
+	// attempts is the number of Bulk API requests the operation was sent in
+	attempts int
+	// result is the outcome of the latest attempt
+	result     bulkResponseItem
+	resultType string
 }
 
 type BufferQueue []*operation
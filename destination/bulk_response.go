@@ -14,7 +14,10 @@
 
 package destination
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"net/http"
+)
 
 type bulkResponse struct {
 	Took   int                 `json:"took"`
@@ -22,6 +25,19 @@ type bulkResponse struct {
 	Items  []bulkResponseItems `json:"items"`
 }
 
+// failuresCount returns the number of items which were not executed successfully.
+func (r bulkResponse) failuresCount() int {
+	var count int
+
+	for _, item := range r.Items {
+		if result, _, ok := item.Result(); ok && (result.Status < 200 || result.Status >= 300) {
+			count++
+		}
+	}
+
+	return count
+}
+
 type bulkResponseItems struct {
 	Index  *bulkResponseItem `json:"index,omitempty"`
 	Create *bulkResponseItem `json:"create,omitempty"`
@@ -29,6 +45,27 @@ type bulkResponseItems struct {
 	Delete *bulkResponseItem `json:"delete,omitempty"`
 }
 
+// Result returns the result of the operation along with the name of executed action.
+// Returns false when the response does not contain any of the supported actions.
+func (i bulkResponseItems) Result() (bulkResponseItem, string, bool) {
+	switch {
+	case i.Index != nil:
+		return *i.Index, "index", true
+
+	case i.Create != nil:
+		return *i.Create, "create", true
+
+	case i.Update != nil:
+		return *i.Update, "update", true
+
+	case i.Delete != nil:
+		return *i.Delete, "delete", true
+
+	default:
+		return bulkResponseItem{}, "", false
+	}
+}
+
 type bulkResponseItem struct {
 	ID     string                 `json:"_id"`
 	Status int                    `json:"status"`
@@ -40,3 +77,26 @@ type bulkResponseItemError struct {
 	Reason   string          `json:"reason"`
 	CausedBy json.RawMessage `json:"caused_by"`
 }
+
+// retryableStatuses lists item statuses which are expected to succeed when retried later.
+var retryableStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// retryableErrorTypes lists item error types caused by a temporary cluster overload.
+var retryableErrorTypes = map[string]bool{
+	"es_rejected_execution_exception": true,
+	"circuit_breaking_exception":      true,
+}
+
+// IsRetryable returns whether the failed item may succeed when retried.
+func (i bulkResponseItem) IsRetryable() bool {
+	if retryableStatuses[i.Status] {
+		return true
+	}
+
+	return i.Error != nil && retryableErrorTypes[i.Error.Type]
+}
@@ -0,0 +1,199 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type bulkWorkersExecuteFunc func(ctx context.Context, operations BufferQueue) error
+
+// bulkWorkersBatch is a batch of operations handed over to a worker.
+type bulkWorkersBatch struct {
+	operations BufferQueue
+
+	// spanContext is the span of the flush which dispatched the batch, it parents spans of the execution
+	spanContext trace.SpanContext
+}
+
+// bulkWorkers executes operations concurrently in a fixed number of workers.
+// Operations are partitioned by the Document ID, so all operations for the same Document
+// are executed by the same worker in the order they were dispatched.
+type bulkWorkers struct {
+	queues  []chan bulkWorkersBatch
+	execute bulkWorkersExecuteFunc
+	cancel  context.CancelFunc
+
+	pending sync.WaitGroup
+	running sync.WaitGroup
+
+	mutex sync.Mutex
+	err   error
+
+	// nextKeyless is the worker which receives the next operation without Document ID
+	nextKeyless int
+}
+
+func newBulkWorkers(ctx context.Context, count int, execute bulkWorkersExecuteFunc) *bulkWorkers {
+	w := &bulkWorkers{
+		queues:  make([]chan bulkWorkersBatch, count),
+		execute: execute,
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	for n := range w.queues {
+		// Buffer a single batch, so the next one can be prepared while the current one is executed
+		w.queues[n] = make(chan bulkWorkersBatch, 1)
+
+		w.running.Add(1)
+
+		go w.run(ctx, w.queues[n])
+	}
+
+	return w
+}
+
+// Dispatch partitions operations between workers and hands them over without waiting for the results.
+// Blocks when a worker has not yet picked up its previous batch.
+// Returns the first error reported by any of the workers, in which case operations are nacked with it.
+// The span of given context becomes the parent of spans created while executing the operations.
+func (w *bulkWorkers) Dispatch(ctx context.Context, operations BufferQueue) error {
+	if err := w.Err(); err != nil {
+		nack(ctx, operations, err)
+
+		return err
+	}
+
+	spanContext := trace.SpanContextFromContext(ctx)
+
+	for n, batch := range w.partition(operations) {
+		if batch.Empty() {
+			continue
+		}
+
+		w.pending.Add(1)
+
+		select {
+		case w.queues[n] <- bulkWorkersBatch{operations: batch, spanContext: spanContext}:
+
+		case <-ctx.Done():
+			w.pending.Done()
+
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Wait blocks until all dispatched operations are executed.
+// Returns the first error reported by any of the workers.
+func (w *bulkWorkers) Wait() error {
+	w.pending.Wait()
+
+	return w.Err()
+}
+
+// Err returns the first error reported by any of the workers.
+func (w *bulkWorkers) Err() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.err
+}
+
+// Close stops all workers once they have executed the dispatched operations.
+// Dispatch must not be called after Close.
+func (w *bulkWorkers) Close() {
+	for _, queue := range w.queues {
+		close(queue)
+	}
+
+	w.running.Wait()
+	w.cancel()
+}
+
+// Abort cancels the execution of operations in progress and skips the dispatched ones which were not executed yet.
+func (w *bulkWorkers) Abort() {
+	w.setErr(context.Canceled)
+	w.cancel()
+}
+
+func (w *bulkWorkers) run(ctx context.Context, queue <-chan bulkWorkersBatch) {
+	defer w.running.Done()
+
+	for batch := range queue {
+		batchCtx := trace.ContextWithSpanContext(ctx, batch.spanContext)
+
+		// Once any of the workers failed, no more requests are sent and the remaining batches are nacked
+		if err := w.Err(); err != nil {
+			nack(batchCtx, batch.operations, err)
+		} else if err := w.execute(batchCtx, batch.operations); err != nil {
+			// Operations acked before the failure are not acked again, as acks are tracked by the destination
+			nack(batchCtx, batch.operations, err)
+			w.setErr(err)
+		}
+
+		w.pending.Done()
+	}
+}
+
+// nack acks all operations with given error.
+func nack(ctx context.Context, operations BufferQueue, err error) {
+	for _, item := range operations {
+		if ackErr := item.AckFunc(err); ackErr != nil {
+			sdk.Logger(ctx).Warn().Err(ackErr).Msg("failed to nack operation")
+		}
+	}
+}
+
+func (w *bulkWorkers) setErr(err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// partition splits operations into one batch per worker, preserving their order.
+// Operations without Document ID are distributed evenly.
+func (w *bulkWorkers) partition(operations BufferQueue) []BufferQueue {
+	batches := make([]BufferQueue, len(w.queues))
+
+	for _, item := range operations {
+		var n int
+
+		if key := recordKey(item.Record); key != "" {
+			hash := fnv.New32a()
+			_, _ = hash.Write([]byte(key))
+
+			n = int(hash.Sum32() % uint32(len(w.queues)))
+		} else {
+			n = w.nextKeyless
+			w.nextKeyless = (w.nextKeyless + 1) % len(w.queues)
+		}
+
+		batches[n].Enqueue(item)
+	}
+
+	return batches
+}
@@ -0,0 +1,194 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkWorkers_Dispatch(t *testing.T) {
+	t.Run("Operations for the same key are executed in order by the same worker", func(t *testing.T) {
+		var (
+			mutex    sync.Mutex
+			sequence = make(map[*operation]int)
+			executed = make(map[string][]int)
+		)
+
+		w := newBulkWorkers(context.Background(), 4, func(ctx context.Context, operations BufferQueue) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			for _, item := range operations {
+				key := recordKey(item.Record)
+
+				executed[key] = append(executed[key], sequence[item])
+			}
+
+			return nil
+		})
+		t.Cleanup(w.Close)
+
+		batches := make([]BufferQueue, 5)
+
+		for batch := range batches {
+			batches[batch] = make(BufferQueue, 0)
+
+			for n := 0; n < 20; n++ {
+				item := &operation{
+					Record: sdk.Record{
+						Key: sdk.RawData(fmt.Sprintf("key-%d", n%7)),
+					},
+				}
+
+				sequence[item] = batch*20 + n
+
+				batches[batch].Enqueue(item)
+			}
+		}
+
+		for _, operations := range batches {
+			require.NoError(t, w.Dispatch(context.Background(), operations))
+		}
+
+		require.NoError(t, w.Wait())
+		require.Len(t, executed, 7)
+
+		for key, sequence := range executed {
+			require.IsIncreasing(t, sequence, "operations for %s were executed out of order", key)
+		}
+	})
+
+	t.Run("Operations are not lost nor duplicated", func(t *testing.T) {
+		var (
+			mutex    sync.Mutex
+			executed = make(map[*operation]int)
+		)
+
+		w := newBulkWorkers(context.Background(), 3, func(ctx context.Context, operations BufferQueue) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			for _, item := range operations {
+				executed[item]++
+			}
+
+			return nil
+		})
+		t.Cleanup(w.Close)
+
+		operations := make(BufferQueue, 0)
+
+		for n := 0; n < 100; n++ {
+			var key sdk.Data
+			if n%2 == 0 {
+				key = sdk.RawData(fmt.Sprintf("key-%d", n))
+			}
+
+			operations.Enqueue(&operation{
+				Record: sdk.Record{
+					Key: key,
+				},
+			})
+		}
+
+		require.NoError(t, w.Dispatch(context.Background(), operations))
+		require.NoError(t, w.Wait())
+		require.Len(t, executed, 100)
+
+		for _, item := range operations {
+			require.Equal(t, 1, executed[item])
+		}
+	})
+
+	t.Run("Returns worker error", func(t *testing.T) {
+		w := newBulkWorkers(context.Background(), 2, func(ctx context.Context, operations BufferQueue) error {
+			return errors.New("bulk request failure")
+		})
+		t.Cleanup(w.Close)
+
+		require.NoError(t, w.Dispatch(context.Background(), BufferQueue{
+			&operation{
+				Record: sdk.Record{
+					Key: sdk.RawData("key"),
+				},
+				AckFunc: func(err error) error {
+					return nil
+				},
+			},
+		}))
+		require.EqualError(t, w.Wait(), "bulk request failure")
+		require.EqualError(t, w.Dispatch(context.Background(), BufferQueue{}), "bulk request failure")
+	})
+
+	t.Run("Nacks failed and skipped operations after worker error", func(t *testing.T) {
+		release := make(chan struct{})
+
+		w := newBulkWorkers(context.Background(), 1, func(ctx context.Context, operations BufferQueue) error {
+			<-release
+
+			return errors.New("bulk request failure")
+		})
+		t.Cleanup(w.Close)
+
+		nacked := make([]string, 0)
+
+		for n := 0; n < 2; n++ {
+			key := fmt.Sprintf("key-%d", n)
+
+			require.NoError(t, w.Dispatch(context.Background(), BufferQueue{
+				&operation{
+					Record: sdk.Record{
+						Key: sdk.RawData(key),
+					},
+					AckFunc: func(err error) error {
+						require.EqualError(t, err, "bulk request failure")
+
+						nacked = append(nacked, key)
+
+						return nil
+					},
+				},
+			}))
+		}
+
+		close(release)
+
+		require.EqualError(t, w.Wait(), "bulk request failure")
+		require.Equal(t, []string{"key-0", "key-1"}, nacked)
+
+		require.EqualError(t, w.Dispatch(context.Background(), BufferQueue{
+			&operation{
+				Record: sdk.Record{
+					Key: sdk.RawData("key-2"),
+				},
+				AckFunc: func(err error) error {
+					require.EqualError(t, err, "bulk request failure")
+
+					nacked = append(nacked, "key-2")
+
+					return nil
+				},
+			},
+		}), "bulk request failure")
+		require.Equal(t, []string{"key-0", "key-1", "key-2"}, nacked)
+	})
+}
@@ -23,18 +23,51 @@ var _ client = &clientMock{}
 // 			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
 // 				panic("mock out the Bulk method")
 // 			},
+// 			CloseIdleConnectionsFunc: func()  {
+// 				panic("mock out the CloseIdleConnections method")
+// 			},
+// 			CreateIndexFunc: func(ctx context.Context, body io.Reader) error {
+// 				panic("mock out the CreateIndex method")
+// 			},
+// 			CreateRolloverIndexFunc: func(ctx context.Context, body io.Reader) error {
+// 				panic("mock out the CreateRolloverIndex method")
+// 			},
+// 			GetComponentTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+// 				panic("mock out the GetComponentTemplate method")
+// 			},
+// 			GetIndexTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+// 				panic("mock out the GetIndexTemplate method")
+// 			},
+// 			GetMappingFunc: func(ctx context.Context) (map[string]interface{}, error) {
+// 				panic("mock out the GetMapping method")
+// 			},
+// 			IndexExistsFunc: func(ctx context.Context) (bool, error) {
+// 				panic("mock out the IndexExists method")
+// 			},
 // 			PingFunc: func(ctx context.Context) error {
 // 				panic("mock out the Ping method")
 // 			},
 // 			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
 // 				panic("mock out the PrepareCreateOperation method")
 // 			},
-// 			PrepareDeleteOperationFunc: func(key string) (interface{}, error) {
+// 			PrepareDeleteOperationFunc: func(key string, version uint64) (interface{}, error) {
 // 				panic("mock out the PrepareDeleteOperation method")
 // 			},
+// 			PrepareIndexOperationFunc: func(key string, item sdk.Record, version uint64) (interface{}, interface{}, error) {
+// 				panic("mock out the PrepareIndexOperation method")
+// 			},
 // 			PrepareUpsertOperationFunc: func(key string, item sdk.Record) (interface{}, interface{}, error) {
 // 				panic("mock out the PrepareUpsertOperation method")
 // 			},
+// 			PutComponentTemplateFunc: func(ctx context.Context, name string, body io.Reader) error {
+// 				panic("mock out the PutComponentTemplate method")
+// 			},
+// 			PutIndexTemplateFunc: func(ctx context.Context, name string, body io.Reader) error {
+// 				panic("mock out the PutIndexTemplate method")
+// 			},
+// 			PutLifecyclePolicyFunc: func(ctx context.Context, name string, body io.Reader) error {
+// 				panic("mock out the PutLifecyclePolicy method")
+// 			},
 // 		}
 //
 // 		// use mockedclient in code that requires client
@@ -45,6 +78,27 @@ type clientMock struct {
 	// BulkFunc mocks the Bulk method.
 	BulkFunc func(ctx context.Context, reader io.Reader) (io.ReadCloser, error)
 
+	// CloseIdleConnectionsFunc mocks the CloseIdleConnections method.
+	CloseIdleConnectionsFunc func() 
+
+	// CreateIndexFunc mocks the CreateIndex method.
+	CreateIndexFunc func(ctx context.Context, body io.Reader) error
+
+	// CreateRolloverIndexFunc mocks the CreateRolloverIndex method.
+	CreateRolloverIndexFunc func(ctx context.Context, body io.Reader) error
+
+	// GetComponentTemplateFunc mocks the GetComponentTemplate method.
+	GetComponentTemplateFunc func(ctx context.Context, name string) (map[string]interface{}, error)
+
+	// GetIndexTemplateFunc mocks the GetIndexTemplate method.
+	GetIndexTemplateFunc func(ctx context.Context, name string) (map[string]interface{}, error)
+
+	// GetMappingFunc mocks the GetMapping method.
+	GetMappingFunc func(ctx context.Context) (map[string]interface{}, error)
+
+	// IndexExistsFunc mocks the IndexExists method.
+	IndexExistsFunc func(ctx context.Context) (bool, error)
+
 	// PingFunc mocks the Ping method.
 	PingFunc func(ctx context.Context) error
 
@@ -52,11 +106,23 @@ type clientMock struct {
 	PrepareCreateOperationFunc func(item sdk.Record) (interface{}, interface{}, error)
 
 	// PrepareDeleteOperationFunc mocks the PrepareDeleteOperation method.
-	PrepareDeleteOperationFunc func(key string) (interface{}, error)
+	PrepareDeleteOperationFunc func(key string, version uint64) (interface{}, error)
+
+	// PrepareIndexOperationFunc mocks the PrepareIndexOperation method.
+	PrepareIndexOperationFunc func(key string, item sdk.Record, version uint64) (interface{}, interface{}, error)
 
 	// PrepareUpsertOperationFunc mocks the PrepareUpsertOperation method.
 	PrepareUpsertOperationFunc func(key string, item sdk.Record) (interface{}, interface{}, error)
 
+	// PutComponentTemplateFunc mocks the PutComponentTemplate method.
+	PutComponentTemplateFunc func(ctx context.Context, name string, body io.Reader) error
+
+	// PutIndexTemplateFunc mocks the PutIndexTemplate method.
+	PutIndexTemplateFunc func(ctx context.Context, name string, body io.Reader) error
+
+	// PutLifecyclePolicyFunc mocks the PutLifecyclePolicy method.
+	PutLifecyclePolicyFunc func(ctx context.Context, name string, body io.Reader) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Bulk holds details about calls to the Bulk method.
@@ -66,6 +132,47 @@ type clientMock struct {
 			// Reader is the reader argument value.
 			Reader io.Reader
 		}
+		// CloseIdleConnections holds details about calls to the CloseIdleConnections method.
+		CloseIdleConnections []struct {
+		}
+		// CreateIndex holds details about calls to the CreateIndex method.
+		CreateIndex []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Body is the body argument value.
+			Body io.Reader
+		}
+		// CreateRolloverIndex holds details about calls to the CreateRolloverIndex method.
+		CreateRolloverIndex []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Body is the body argument value.
+			Body io.Reader
+		}
+		// GetComponentTemplate holds details about calls to the GetComponentTemplate method.
+		GetComponentTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// GetIndexTemplate holds details about calls to the GetIndexTemplate method.
+		GetIndexTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// GetMapping holds details about calls to the GetMapping method.
+		GetMapping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// IndexExists holds details about calls to the IndexExists method.
+		IndexExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// Ping holds details about calls to the Ping method.
 		Ping []struct {
 			// Ctx is the ctx argument value.
@@ -80,6 +187,17 @@ type clientMock struct {
 		PrepareDeleteOperation []struct {
 			// Key is the key argument value.
 			Key string
+			// Version is the version argument value.
+			Version uint64
+		}
+		// PrepareIndexOperation holds details about calls to the PrepareIndexOperation method.
+		PrepareIndexOperation []struct {
+			// Key is the key argument value.
+			Key string
+			// Item is the item argument value.
+			Item sdk.Record
+			// Version is the version argument value.
+			Version uint64
 		}
 		// PrepareUpsertOperation holds details about calls to the PrepareUpsertOperation method.
 		PrepareUpsertOperation []struct {
@@ -88,12 +206,50 @@ type clientMock struct {
 			// Item is the item argument value.
 			Item sdk.Record
 		}
+		// PutComponentTemplate holds details about calls to the PutComponentTemplate method.
+		PutComponentTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Body is the body argument value.
+			Body io.Reader
+		}
+		// PutIndexTemplate holds details about calls to the PutIndexTemplate method.
+		PutIndexTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Body is the body argument value.
+			Body io.Reader
+		}
+		// PutLifecyclePolicy holds details about calls to the PutLifecyclePolicy method.
+		PutLifecyclePolicy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Body is the body argument value.
+			Body io.Reader
+		}
 	}
 	lockBulk                   sync.RWMutex
+	lockCloseIdleConnections   sync.RWMutex
+	lockCreateIndex            sync.RWMutex
+	lockCreateRolloverIndex    sync.RWMutex
+	lockGetComponentTemplate   sync.RWMutex
+	lockGetIndexTemplate       sync.RWMutex
+	lockGetMapping             sync.RWMutex
+	lockIndexExists            sync.RWMutex
 	lockPing                   sync.RWMutex
 	lockPrepareCreateOperation sync.RWMutex
 	lockPrepareDeleteOperation sync.RWMutex
+	lockPrepareIndexOperation  sync.RWMutex
 	lockPrepareUpsertOperation sync.RWMutex
+	lockPutComponentTemplate   sync.RWMutex
+	lockPutIndexTemplate       sync.RWMutex
+	lockPutLifecyclePolicy     sync.RWMutex
 }
 
 // Bulk calls BulkFunc.
@@ -131,6 +287,234 @@ func (mock *clientMock) BulkCalls() []struct {
 	return calls
 }
 
+// CloseIdleConnections calls CloseIdleConnectionsFunc.
+func (mock *clientMock) CloseIdleConnections()  {
+	if mock.CloseIdleConnectionsFunc == nil {
+		panic("clientMock.CloseIdleConnectionsFunc: method is nil but client.CloseIdleConnections was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCloseIdleConnections.Lock()
+	mock.calls.CloseIdleConnections = append(mock.calls.CloseIdleConnections, callInfo)
+	mock.lockCloseIdleConnections.Unlock()
+	mock.CloseIdleConnectionsFunc()
+}
+
+// CloseIdleConnectionsCalls gets all the calls that were made to CloseIdleConnections.
+// Check the length with:
+//     len(mockedclient.CloseIdleConnectionsCalls())
+func (mock *clientMock) CloseIdleConnectionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCloseIdleConnections.RLock()
+	calls = mock.calls.CloseIdleConnections
+	mock.lockCloseIdleConnections.RUnlock()
+	return calls
+}
+
+// CreateIndex calls CreateIndexFunc.
+func (mock *clientMock) CreateIndex(ctx context.Context, body io.Reader) error {
+	if mock.CreateIndexFunc == nil {
+		panic("clientMock.CreateIndexFunc: method is nil but client.CreateIndex was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Body io.Reader
+	}{
+		Ctx:  ctx,
+		Body: body,
+	}
+	mock.lockCreateIndex.Lock()
+	mock.calls.CreateIndex = append(mock.calls.CreateIndex, callInfo)
+	mock.lockCreateIndex.Unlock()
+	return mock.CreateIndexFunc(ctx, body)
+}
+
+// CreateIndexCalls gets all the calls that were made to CreateIndex.
+// Check the length with:
+//     len(mockedclient.CreateIndexCalls())
+func (mock *clientMock) CreateIndexCalls() []struct {
+	Ctx  context.Context
+	Body io.Reader
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Body io.Reader
+	}
+	mock.lockCreateIndex.RLock()
+	calls = mock.calls.CreateIndex
+	mock.lockCreateIndex.RUnlock()
+	return calls
+}
+
+// CreateRolloverIndex calls CreateRolloverIndexFunc.
+func (mock *clientMock) CreateRolloverIndex(ctx context.Context, body io.Reader) error {
+	if mock.CreateRolloverIndexFunc == nil {
+		panic("clientMock.CreateRolloverIndexFunc: method is nil but client.CreateRolloverIndex was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Body io.Reader
+	}{
+		Ctx:  ctx,
+		Body: body,
+	}
+	mock.lockCreateRolloverIndex.Lock()
+	mock.calls.CreateRolloverIndex = append(mock.calls.CreateRolloverIndex, callInfo)
+	mock.lockCreateRolloverIndex.Unlock()
+	return mock.CreateRolloverIndexFunc(ctx, body)
+}
+
+// CreateRolloverIndexCalls gets all the calls that were made to CreateRolloverIndex.
+// Check the length with:
+//     len(mockedclient.CreateRolloverIndexCalls())
+func (mock *clientMock) CreateRolloverIndexCalls() []struct {
+	Ctx  context.Context
+	Body io.Reader
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Body io.Reader
+	}
+	mock.lockCreateRolloverIndex.RLock()
+	calls = mock.calls.CreateRolloverIndex
+	mock.lockCreateRolloverIndex.RUnlock()
+	return calls
+}
+
+// GetComponentTemplate calls GetComponentTemplateFunc.
+func (mock *clientMock) GetComponentTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	if mock.GetComponentTemplateFunc == nil {
+		panic("clientMock.GetComponentTemplateFunc: method is nil but client.GetComponentTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetComponentTemplate.Lock()
+	mock.calls.GetComponentTemplate = append(mock.calls.GetComponentTemplate, callInfo)
+	mock.lockGetComponentTemplate.Unlock()
+	return mock.GetComponentTemplateFunc(ctx, name)
+}
+
+// GetComponentTemplateCalls gets all the calls that were made to GetComponentTemplate.
+// Check the length with:
+//     len(mockedclient.GetComponentTemplateCalls())
+func (mock *clientMock) GetComponentTemplateCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetComponentTemplate.RLock()
+	calls = mock.calls.GetComponentTemplate
+	mock.lockGetComponentTemplate.RUnlock()
+	return calls
+}
+
+// GetIndexTemplate calls GetIndexTemplateFunc.
+func (mock *clientMock) GetIndexTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	if mock.GetIndexTemplateFunc == nil {
+		panic("clientMock.GetIndexTemplateFunc: method is nil but client.GetIndexTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetIndexTemplate.Lock()
+	mock.calls.GetIndexTemplate = append(mock.calls.GetIndexTemplate, callInfo)
+	mock.lockGetIndexTemplate.Unlock()
+	return mock.GetIndexTemplateFunc(ctx, name)
+}
+
+// GetIndexTemplateCalls gets all the calls that were made to GetIndexTemplate.
+// Check the length with:
+//     len(mockedclient.GetIndexTemplateCalls())
+func (mock *clientMock) GetIndexTemplateCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetIndexTemplate.RLock()
+	calls = mock.calls.GetIndexTemplate
+	mock.lockGetIndexTemplate.RUnlock()
+	return calls
+}
+
+// GetMapping calls GetMappingFunc.
+func (mock *clientMock) GetMapping(ctx context.Context) (map[string]interface{}, error) {
+	if mock.GetMappingFunc == nil {
+		panic("clientMock.GetMappingFunc: method is nil but client.GetMapping was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetMapping.Lock()
+	mock.calls.GetMapping = append(mock.calls.GetMapping, callInfo)
+	mock.lockGetMapping.Unlock()
+	return mock.GetMappingFunc(ctx)
+}
+
+// GetMappingCalls gets all the calls that were made to GetMapping.
+// Check the length with:
+//     len(mockedclient.GetMappingCalls())
+func (mock *clientMock) GetMappingCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetMapping.RLock()
+	calls = mock.calls.GetMapping
+	mock.lockGetMapping.RUnlock()
+	return calls
+}
+
+// IndexExists calls IndexExistsFunc.
+func (mock *clientMock) IndexExists(ctx context.Context) (bool, error) {
+	if mock.IndexExistsFunc == nil {
+		panic("clientMock.IndexExistsFunc: method is nil but client.IndexExists was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockIndexExists.Lock()
+	mock.calls.IndexExists = append(mock.calls.IndexExists, callInfo)
+	mock.lockIndexExists.Unlock()
+	return mock.IndexExistsFunc(ctx)
+}
+
+// IndexExistsCalls gets all the calls that were made to IndexExists.
+// Check the length with:
+//     len(mockedclient.IndexExistsCalls())
+func (mock *clientMock) IndexExistsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockIndexExists.RLock()
+	calls = mock.calls.IndexExists
+	mock.lockIndexExists.RUnlock()
+	return calls
+}
+
 // Ping calls PingFunc.
 func (mock *clientMock) Ping(ctx context.Context) error {
 	if mock.PingFunc == nil {
@@ -194,29 +578,33 @@ func (mock *clientMock) PrepareCreateOperationCalls() []struct {
 }
 
 // PrepareDeleteOperation calls PrepareDeleteOperationFunc.
-func (mock *clientMock) PrepareDeleteOperation(key string) (interface{}, error) {
+func (mock *clientMock) PrepareDeleteOperation(key string, version uint64) (interface{}, error) {
 	if mock.PrepareDeleteOperationFunc == nil {
 		panic("clientMock.PrepareDeleteOperationFunc: method is nil but client.PrepareDeleteOperation was just called")
 	}
 	callInfo := struct {
-		Key string
+		Key     string
+		Version uint64
 	}{
-		Key: key,
+		Key:     key,
+		Version: version,
 	}
 	mock.lockPrepareDeleteOperation.Lock()
 	mock.calls.PrepareDeleteOperation = append(mock.calls.PrepareDeleteOperation, callInfo)
 	mock.lockPrepareDeleteOperation.Unlock()
-	return mock.PrepareDeleteOperationFunc(key)
+	return mock.PrepareDeleteOperationFunc(key, version)
 }
 
 // PrepareDeleteOperationCalls gets all the calls that were made to PrepareDeleteOperation.
 // Check the length with:
 //     len(mockedclient.PrepareDeleteOperationCalls())
 func (mock *clientMock) PrepareDeleteOperationCalls() []struct {
-	Key string
+	Key     string
+	Version uint64
 } {
 	var calls []struct {
-		Key string
+		Key     string
+		Version uint64
 	}
 	mock.lockPrepareDeleteOperation.RLock()
 	calls = mock.calls.PrepareDeleteOperation
@@ -224,6 +612,45 @@ func (mock *clientMock) PrepareDeleteOperationCalls() []struct {
 	return calls
 }
 
+// PrepareIndexOperation calls PrepareIndexOperationFunc.
+func (mock *clientMock) PrepareIndexOperation(key string, item sdk.Record, version uint64) (interface{}, interface{}, error) {
+	if mock.PrepareIndexOperationFunc == nil {
+		panic("clientMock.PrepareIndexOperationFunc: method is nil but client.PrepareIndexOperation was just called")
+	}
+	callInfo := struct {
+		Key     string
+		Item    sdk.Record
+		Version uint64
+	}{
+		Key:     key,
+		Item:    item,
+		Version: version,
+	}
+	mock.lockPrepareIndexOperation.Lock()
+	mock.calls.PrepareIndexOperation = append(mock.calls.PrepareIndexOperation, callInfo)
+	mock.lockPrepareIndexOperation.Unlock()
+	return mock.PrepareIndexOperationFunc(key, item, version)
+}
+
+// PrepareIndexOperationCalls gets all the calls that were made to PrepareIndexOperation.
+// Check the length with:
+//     len(mockedclient.PrepareIndexOperationCalls())
+func (mock *clientMock) PrepareIndexOperationCalls() []struct {
+	Key     string
+	Item    sdk.Record
+	Version uint64
+} {
+	var calls []struct {
+		Key     string
+		Item    sdk.Record
+		Version uint64
+	}
+	mock.lockPrepareIndexOperation.RLock()
+	calls = mock.calls.PrepareIndexOperation
+	mock.lockPrepareIndexOperation.RUnlock()
+	return calls
+}
+
 // PrepareUpsertOperation calls PrepareUpsertOperationFunc.
 func (mock *clientMock) PrepareUpsertOperation(key string, item sdk.Record) (interface{}, interface{}, error) {
 	if mock.PrepareUpsertOperationFunc == nil {
@@ -258,3 +685,120 @@ func (mock *clientMock) PrepareUpsertOperationCalls() []struct {
 	mock.lockPrepareUpsertOperation.RUnlock()
 	return calls
 }
+
+// PutComponentTemplate calls PutComponentTemplateFunc.
+func (mock *clientMock) PutComponentTemplate(ctx context.Context, name string, body io.Reader) error {
+	if mock.PutComponentTemplateFunc == nil {
+		panic("clientMock.PutComponentTemplateFunc: method is nil but client.PutComponentTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+		Body io.Reader
+	}{
+		Ctx:  ctx,
+		Name: name,
+		Body: body,
+	}
+	mock.lockPutComponentTemplate.Lock()
+	mock.calls.PutComponentTemplate = append(mock.calls.PutComponentTemplate, callInfo)
+	mock.lockPutComponentTemplate.Unlock()
+	return mock.PutComponentTemplateFunc(ctx, name, body)
+}
+
+// PutComponentTemplateCalls gets all the calls that were made to PutComponentTemplate.
+// Check the length with:
+//     len(mockedclient.PutComponentTemplateCalls())
+func (mock *clientMock) PutComponentTemplateCalls() []struct {
+	Ctx  context.Context
+	Name string
+	Body io.Reader
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+		Body io.Reader
+	}
+	mock.lockPutComponentTemplate.RLock()
+	calls = mock.calls.PutComponentTemplate
+	mock.lockPutComponentTemplate.RUnlock()
+	return calls
+}
+
+// PutIndexTemplate calls PutIndexTemplateFunc.
+func (mock *clientMock) PutIndexTemplate(ctx context.Context, name string, body io.Reader) error {
+	if mock.PutIndexTemplateFunc == nil {
+		panic("clientMock.PutIndexTemplateFunc: method is nil but client.PutIndexTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+		Body io.Reader
+	}{
+		Ctx:  ctx,
+		Name: name,
+		Body: body,
+	}
+	mock.lockPutIndexTemplate.Lock()
+	mock.calls.PutIndexTemplate = append(mock.calls.PutIndexTemplate, callInfo)
+	mock.lockPutIndexTemplate.Unlock()
+	return mock.PutIndexTemplateFunc(ctx, name, body)
+}
+
+// PutIndexTemplateCalls gets all the calls that were made to PutIndexTemplate.
+// Check the length with:
+//     len(mockedclient.PutIndexTemplateCalls())
+func (mock *clientMock) PutIndexTemplateCalls() []struct {
+	Ctx  context.Context
+	Name string
+	Body io.Reader
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+		Body io.Reader
+	}
+	mock.lockPutIndexTemplate.RLock()
+	calls = mock.calls.PutIndexTemplate
+	mock.lockPutIndexTemplate.RUnlock()
+	return calls
+}
+
+// PutLifecyclePolicy calls PutLifecyclePolicyFunc.
+func (mock *clientMock) PutLifecyclePolicy(ctx context.Context, name string, body io.Reader) error {
+	if mock.PutLifecyclePolicyFunc == nil {
+		panic("clientMock.PutLifecyclePolicyFunc: method is nil but client.PutLifecyclePolicy was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+		Body io.Reader
+	}{
+		Ctx:  ctx,
+		Name: name,
+		Body: body,
+	}
+	mock.lockPutLifecyclePolicy.Lock()
+	mock.calls.PutLifecyclePolicy = append(mock.calls.PutLifecyclePolicy, callInfo)
+	mock.lockPutLifecyclePolicy.Unlock()
+	return mock.PutLifecyclePolicyFunc(ctx, name, body)
+}
+
+// PutLifecyclePolicyCalls gets all the calls that were made to PutLifecyclePolicy.
+// Check the length with:
+//     len(mockedclient.PutLifecyclePolicyCalls())
+func (mock *clientMock) PutLifecyclePolicyCalls() []struct {
+	Ctx  context.Context
+	Name string
+	Body io.Reader
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+		Body io.Reader
+	}
+	mock.lockPutLifecyclePolicy.RLock()
+	calls = mock.calls.PutLifecyclePolicy
+	mock.lockPutLifecyclePolicy.RUnlock()
+	return calls
+}
@@ -15,47 +15,166 @@
 package destination
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
 	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+	"golang.org/x/net/http/httpguts"
+	"golang.org/x/net/http/httpproxy"
 )
 
 const (
-	ConfigKeyVersion                = "version"
-	ConfigKeyHost                   = "host"
-	ConfigKeyUsername               = "username"
-	ConfigKeyPassword               = "password"
-	ConfigKeyCloudID                = "cloudId"
-	ConfigKeyAPIKey                 = "apiKey"
-	ConfigKeyServiceToken           = "serviceToken"
-	ConfigKeyCertificateFingerprint = "certificateFingerprint"
-	ConfigKeyIndex                  = "index"
-	ConfigKeyType                   = "type"
-	ConfigKeyBulkSize               = "bulkSize"
-	ConfigKeyRetries                = "retries"
+	ConfigKeyVersion                 = "version"
+	ConfigKeyHost                    = "host"
+	ConfigKeyUsername                = "username"
+	ConfigKeyPassword                = "password"
+	ConfigKeyCloudID                 = "cloudId"
+	ConfigKeyAPIKey                  = "apiKey"
+	ConfigKeyServiceToken            = "serviceToken"
+	ConfigKeyBearerToken             = "bearerToken"
+	ConfigKeyCertificateFingerprint  = "certificateFingerprint"
+	ConfigKeyIndex                   = "index"
+	ConfigKeyType                    = "type"
+	ConfigKeyBulkSize                = "bulkSize"
+	ConfigKeyBulkMaxBytes            = "bulkMaxBytes"
+	ConfigKeyBulkWorkers             = "bulkWorkers"
+	ConfigKeyRetries                 = "retries"
+	ConfigKeyRetryInitialDelay       = "retryInitialDelay"
+	ConfigKeyRetryMaxDelay           = "retryMaxDelay"
+	ConfigKeyRetryMultiplier         = "retryMultiplier"
+	ConfigKeyRetryJitter             = "retryJitter"
+	ConfigKeyDrainTimeout            = "drainTimeout"
+	ConfigKeyDeadLetterIndex         = "deadLetterIndex"
+	ConfigKeyFailurePolicies         = "failurePolicies"
+	ConfigKeyIndexSettings           = "indexSettings"
+	ConfigKeyIndexSettingsFile       = "indexSettingsFile"
+	ConfigKeyIndexTemplate           = "indexTemplate"
+	ConfigKeyIndexTemplateFile       = "indexTemplateFile"
+	ConfigKeyIndexTemplateName       = "indexTemplateName"
+	ConfigKeyComponentTemplates      = "componentTemplates"
+	ConfigKeyComponentTemplatesFile  = "componentTemplatesFile"
+	ConfigKeyTemplateVersion         = "templateVersion"
+	ConfigKeyILMPolicy               = "ilmPolicy"
+	ConfigKeyILMRolloverMaxAge       = "ilmRolloverMaxAge"
+	ConfigKeyILMRolloverMaxSize      = "ilmRolloverMaxSize"
+	ConfigKeyILMRolloverMaxDocs      = "ilmRolloverMaxDocs"
+	ConfigKeyILMWarmMinAge           = "ilmWarmMinAge"
+	ConfigKeyILMDeleteMinAge         = "ilmDeleteMinAge"
+	ConfigKeyMappingInferenceSamples = "mappingInferenceSamples"
+	ConfigKeyFieldsNesting           = "fieldsNesting"
+	ConfigKeyFieldsInclude           = "fieldsInclude"
+	ConfigKeyFieldsExclude           = "fieldsExclude"
+	ConfigKeyFieldsRename            = "fieldsRename"
+	ConfigKeyFieldsConstant          = "fieldsConstant"
+	ConfigKeyDebeziumEnvelope        = "debeziumEnvelope"
+	ConfigKeyDebeziumVersioning      = "debeziumVersioning"
+	ConfigKeyContentHashID           = "contentHashId"
+	ConfigKeyContentHashFields       = "contentHashFields"
+	ConfigKeyCompression             = "compression"
+	ConfigKeySniffOnStart            = "sniffOnStart"
+	ConfigKeySniffOnFailure          = "sniffOnFailure"
+	ConfigKeyTLSCACertFile           = "tlsCaCertFile"
+	ConfigKeyTLSClientCertFile       = "tlsClientCertFile"
+	ConfigKeyTLSClientKeyFile        = "tlsClientKeyFile"
+	ConfigKeyTLSMinVersion           = "tlsMinVersion"
+	ConfigKeyTLSInsecureSkipVerify   = "tlsInsecureSkipVerify"
+	ConfigKeyProxyURL                = "proxyUrl"
+	ConfigKeyNoProxy                 = "noProxy"
+	ConfigKeyHeaders                 = "headers"
+	ConfigKeyAWSSigV4                = "awsSigV4"
+	ConfigKeyAWSRegion               = "awsRegion"
+	ConfigKeyAWSService              = "awsService"
+	ConfigKeyAWSCredentials          = "awsCredentials"
+	ConfigKeyAWSAccessKeyID          = "awsAccessKeyId"
+	ConfigKeyAWSSecretAccessKey      = "awsSecretAccessKey"
+	ConfigKeyAWSSessionToken         = "awsSessionToken"
+	ConfigKeyAWSRoleARN              = "awsRoleArn"
+	ConfigKeyAWSWebIdentityTokenFile = "awsWebIdentityTokenFile"
+	ConfigKeyAWSRoleSessionName      = "awsRoleSessionName"
+	ConfigKeyMetricsAddress          = "metricsAddress"
 )
 
 type Config struct {
-	Version                elasticsearch.Version
-	Host                   string
-	Username               string
-	Password               string
-	CloudID                string
-	APIKey                 string
-	ServiceToken           string
-	CertificateFingerprint string
-	Index                  string
-	Type                   string
-	BulkSize               uint64
-	Retries                uint8
+	Version                 elasticsearch.Version
+	Host                    string
+	Username                string
+	Password                string
+	CloudID                 string
+	APIKey                  string
+	ServiceToken            string
+	BearerToken             string
+	CertificateFingerprint  string
+	Index                   string
+	Type                    string
+	BulkSize                uint64
+	BulkMaxBytes            uint64
+	BulkWorkers             uint8
+	Retries                 uint8
+	RetryInitialDelay       time.Duration
+	RetryMaxDelay           time.Duration
+	RetryMultiplier         float64
+	RetryJitter             float64
+	DrainTimeout            time.Duration
+	DeadLetterIndex         string
+	FailurePolicies         failurePolicies
+	IndexSettings           []byte
+	IndexTemplate           []byte
+	IndexTemplateName       string
+	ComponentTemplates      map[string]json.RawMessage
+	TemplateVersion         uint64
+	ILMPolicy               string
+	ILMRolloverMaxAge       string
+	ILMRolloverMaxSize      string
+	ILMRolloverMaxDocs      uint64
+	ILMWarmMinAge           string
+	ILMDeleteMinAge         string
+	MappingInferenceSamples uint64
+	FieldTransformations    fieldTransformations
+	DebeziumEnvelope        bool
+	DebeziumVersioning      bool
+	ContentHashID           bool
+	ContentHashFields       []string
+	Compression             internal.Compression
+	Hosts                   []string
+	SniffOnStart            bool
+	SniffOnFailure          bool
+	TLS                     *tls.Config
+	ProxyURL                string
+	NoProxy                 []string
+	// Header values may contain secrets, so they must never be logged
+	Header         http.Header
+	Signer         *sigv4.Signer
+	MetricsAddress string
 }
 
 func (c Config) GetHost() string {
 	return c.Host
 }
 
+func (c Config) GetHosts() []string {
+	return c.Hosts
+}
+
+func (c Config) GetSniffOnStart() bool {
+	return c.SniffOnStart
+}
+
+func (c Config) GetSniffOnFailure() bool {
+	return c.SniffOnFailure
+}
+
 func (c Config) GetUsername() string {
 	return c.Username
 }
@@ -76,6 +195,10 @@ func (c Config) GetServiceToken() string {
 	return c.ServiceToken
 }
 
+func (c Config) GetBearerToken() string {
+	return c.BearerToken
+}
+
 func (c Config) GetCertificateFingerprint() string {
 	return c.CertificateFingerprint
 }
@@ -84,10 +207,163 @@ func (c Config) GetIndex() string {
 	return c.Index
 }
 
+func (c Config) GetCompression() string {
+	return c.Compression
+}
+
+func (c Config) GetTLSConfig() *tls.Config {
+	return c.TLS
+}
+
+// GetProxy returns the proxy of requests to Elasticsearch, or nil when the proxy was not configured.
+func (c Config) GetProxy() internal.ProxyFunc {
+	if c.ProxyURL == "" {
+		return nil
+	}
+
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  c.ProxyURL,
+		HTTPSProxy: c.ProxyURL,
+		NoProxy:    strings.Join(c.NoProxy, ","),
+	}).ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
+
+func (c Config) GetHeader() http.Header {
+	return c.Header
+}
+
+func (c Config) GetSigner() *sigv4.Signer {
+	return c.Signer
+}
+
 func (c Config) GetType() string {
 	return c.Type
 }
 
+// retryBackoff returns the policy of delays between retries of failed operations.
+func (c Config) retryBackoff() backoff {
+	return backoff{
+		InitialDelay: c.RetryInitialDelay,
+		MaxDelay:     c.RetryMaxDelay,
+		Multiplier:   c.RetryMultiplier,
+		Jitter:       c.RetryJitter,
+	}
+}
+
+// legacyTemplates reports whether the configured version supports only legacy index templates.
+func (c Config) legacyTemplates() bool {
+	return c.Version == elasticsearch.Version5 || c.Version == elasticsearch.Version6
+}
+
+// openSearch reports whether the configured version is one of OpenSearch versions.
+func (c Config) openSearch() bool {
+	return c.Version == elasticsearch.VersionOpenSearch1 || c.Version == elasticsearch.VersionOpenSearch2
+}
+
+// checkVersionSupport validates config values supported only by some versions.
+// It is called once the version of the server is detected for automatic version.
+func (c Config) checkVersionSupport() error {
+	// Cloud ID, API keys and service tokens are features of Elasticsearch security
+	if c.openSearch() {
+		for _, entry := range []struct {
+			key   string
+			value string
+		}{
+			{key: ConfigKeyCloudID, value: c.CloudID},
+			{key: ConfigKeyAPIKey, value: c.APIKey},
+			{key: ConfigKeyServiceToken, value: c.ServiceToken},
+		} {
+			if entry.value != "" {
+				return fmt.Errorf("%q config value is not supported by OpenSearch", entry.key)
+			}
+		}
+	}
+
+	// OpenSearch manages index lifecycle with ISM, not ILM
+	if c.openSearch() && c.ILMPolicy != "" {
+		return fmt.Errorf("%q config value is not supported by OpenSearch", ConfigKeyILMPolicy)
+	}
+
+	// Bearer tokens are issued by the JWT and OpenID Connect authentication of OpenSearch security
+	if !c.openSearch() && c.BearerToken != "" {
+		return fmt.Errorf(
+			"%q config value is supported only for versions %s and %s",
+			ConfigKeyBearerToken,
+			elasticsearch.VersionOpenSearch1,
+			elasticsearch.VersionOpenSearch2,
+		)
+	}
+
+	// The generic client sends plain HTTP requests to configured hosts
+	if c.Version == elasticsearch.VersionGeneric {
+		for _, entry := range []struct {
+			key     string
+			enabled bool
+		}{
+			{key: ConfigKeyCloudID, enabled: c.CloudID != ""},
+			{key: ConfigKeyCertificateFingerprint, enabled: c.CertificateFingerprint != ""},
+			{key: ConfigKeySniffOnStart, enabled: c.SniffOnStart},
+			{key: ConfigKeySniffOnFailure, enabled: c.SniffOnFailure},
+		} {
+			if entry.enabled {
+				return fmt.Errorf("%q config value is not supported by version %s", entry.key, elasticsearch.VersionGeneric)
+			}
+		}
+	}
+
+	if c.legacyTemplates() && c.Type == "" {
+		return requiredConfigErr(ConfigKeyType)
+	}
+
+	if c.ComponentTemplates != nil && c.legacyTemplates() {
+		return fmt.Errorf(
+			"%q config value is supported only for versions %s and %s",
+			ConfigKeyComponentTemplates,
+			elasticsearch.Version7,
+			elasticsearch.Version8,
+		)
+	}
+
+	if c.Version == elasticsearch.Version5 {
+		for _, entry := range []struct {
+			key     string
+			enabled bool
+			since   string
+		}{
+			{key: ConfigKeyILMPolicy, enabled: c.ILMPolicy != "", since: "6.6"},
+			{key: ConfigKeySniffOnStart, enabled: c.SniffOnStart, since: "6"},
+			{key: ConfigKeySniffOnFailure, enabled: c.SniffOnFailure, since: "6"},
+		} {
+			if entry.enabled {
+				return fmt.Errorf("%q config value is supported only for versions %s and newer", entry.key, entry.since)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveVersion sets the version detected for automatic version and prepares config values depending on it.
+func (c *Config) resolveVersion(version elasticsearch.Version) (err error) {
+	c.Version = version
+
+	if err := c.checkVersionSupport(); err != nil {
+		return err
+	}
+
+	if c.ILMPolicy != "" && c.IndexTemplate == nil {
+		if c.IndexTemplate, err = json.Marshal(c.lifecycleIndexTemplate()); err != nil {
+			return fmt.Errorf("failed to prepare index template: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func ParseConfig(cfgRaw map[string]string) (_ Config, err error) {
 	cfg := Config{
 		Version:                cfgRaw[ConfigKeyVersion],
@@ -97,9 +373,13 @@ func ParseConfig(cfgRaw map[string]string) (_ Config, err error) {
 		CloudID:                cfgRaw[ConfigKeyCloudID],
 		APIKey:                 cfgRaw[ConfigKeyAPIKey],
 		ServiceToken:           cfgRaw[ConfigKeyServiceToken],
+		BearerToken:            cfgRaw[ConfigKeyBearerToken],
 		CertificateFingerprint: cfgRaw[ConfigKeyCertificateFingerprint],
 		Index:                  cfgRaw[ConfigKeyIndex],
 		Type:                   cfgRaw[ConfigKeyType],
+		DeadLetterIndex:        cfgRaw[ConfigKeyDeadLetterIndex],
+		IndexTemplateName:      cfgRaw[ConfigKeyIndexTemplateName],
+		ILMPolicy:              cfgRaw[ConfigKeyILMPolicy],
 	}
 
 	if cfg.Version == "" {
@@ -108,7 +388,10 @@ func ParseConfig(cfgRaw map[string]string) (_ Config, err error) {
 	if cfg.Version != elasticsearch.Version5 &&
 		cfg.Version != elasticsearch.Version6 &&
 		cfg.Version != elasticsearch.Version7 &&
-		cfg.Version != elasticsearch.Version8 {
+		cfg.Version != elasticsearch.Version8 &&
+		cfg.Version != elasticsearch.VersionGeneric &&
+		cfg.Version != elasticsearch.VersionAuto &&
+		!cfg.openSearch() {
 		return Config{}, fmt.Errorf(
 			"%q config value must be one of [%s], %s provided",
 			ConfigKeyVersion,
@@ -117,12 +400,16 @@ func ParseConfig(cfgRaw map[string]string) (_ Config, err error) {
 				elasticsearch.Version6,
 				elasticsearch.Version7,
 				elasticsearch.Version8,
+				elasticsearch.VersionOpenSearch1,
+				elasticsearch.VersionOpenSearch2,
+				elasticsearch.VersionGeneric,
+				elasticsearch.VersionAuto,
 			}, ", "),
 			cfg.Version,
 		)
 	}
 
-	if cfg.Host == "" {
+	if cfg.Hosts = splitConfigList(cfg.Host); len(cfg.Hosts) == 0 {
 		return Config{}, requiredConfigErr(ConfigKeyHost)
 	}
 
@@ -134,7 +421,11 @@ func ParseConfig(cfgRaw map[string]string) (_ Config, err error) {
 		return Config{}, requiredConfigErr(ConfigKeyIndex)
 	}
 
-	if (cfg.Version == elasticsearch.Version5 || cfg.Version == elasticsearch.Version6) && cfg.Type == "" {
+	if cfg.DeadLetterIndex != "" && cfg.DeadLetterIndex == cfg.Index {
+		return Config{}, fmt.Errorf("%q config value must be different than %q", ConfigKeyDeadLetterIndex, ConfigKeyIndex)
+	}
+
+	if cfg.legacyTemplates() && cfg.Type == "" {
 		return Config{}, requiredConfigErr(ConfigKeyType)
 	}
 
@@ -143,14 +434,179 @@ func ParseConfig(cfgRaw map[string]string) (_ Config, err error) {
 		return Config{}, err
 	}
 
+	// Bulk max bytes
+	if cfg.BulkMaxBytes, err = parseBulkMaxBytesConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	// Bulk workers
+	if cfg.BulkWorkers, err = parseBulkWorkersConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
 	// Retries
 	if cfg.Retries, err = parseRetriesConfigValue(cfgRaw); err != nil {
 		return Config{}, err
 	}
 
+	// Retries backoff
+	if cfg.RetryInitialDelay, err = parseDurationConfigValue(cfgRaw, ConfigKeyRetryInitialDelay, 100*time.Millisecond); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.RetryMaxDelay, err = parseDurationConfigValue(cfgRaw, ConfigKeyRetryMaxDelay, 30*time.Second); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.RetryMultiplier, err = parseRetryMultiplierConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.RetryJitter, err = parseRetryJitterConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	// Drain timeout
+	if cfg.DrainTimeout, err = parseDrainTimeoutConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	// Failure policies
+	if cfg.FailurePolicies, err = parseFailurePoliciesConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	// Index settings
+	if cfg.IndexSettings, err = parseJSONConfigValue(cfgRaw, ConfigKeyIndexSettings, ConfigKeyIndexSettingsFile); err != nil {
+		return Config{}, err
+	}
+
+	// Templates
+	if cfg.IndexTemplate, err = parseJSONConfigValue(cfgRaw, ConfigKeyIndexTemplate, ConfigKeyIndexTemplateFile); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.IndexTemplateName == "" {
+		cfg.IndexTemplateName = cfg.Index
+	}
+
+	if cfg.ComponentTemplates, err = parseComponentTemplatesConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.TemplateVersion, err = parseTemplateVersionConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	// Index lifecycle management
+	if err := parseILMConfigValues(cfgRaw, &cfg); err != nil {
+		return Config{}, err
+	}
+
+	// Mapping inference
+	if cfg.MappingInferenceSamples, err = parseMappingInferenceSamplesConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	// Field transformations
+	if cfg.FieldTransformations, err = parseFieldTransformationsConfigValues(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	// Debezium envelope
+	if cfg.DebeziumEnvelope, err = parseBoolConfigValue(cfgRaw, ConfigKeyDebeziumEnvelope); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.DebeziumVersioning, err = parseBoolConfigValue(cfgRaw, ConfigKeyDebeziumVersioning); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.DebeziumVersioning && !cfg.DebeziumEnvelope {
+		return Config{}, fmt.Errorf("%q config value must be enabled when %q is provided", ConfigKeyDebeziumEnvelope, ConfigKeyDebeziumVersioning)
+	}
+
+	// Compression
+	if cfg.Compression, err = parseCompressionConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	// Node sniffing
+	if cfg.SniffOnStart, err = parseBoolConfigValue(cfgRaw, ConfigKeySniffOnStart); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.SniffOnFailure, err = parseBoolConfigValue(cfgRaw, ConfigKeySniffOnFailure); err != nil {
+		return Config{}, err
+	}
+
+	// TLS
+	if cfg.TLS, err = parseTLSConfigValues(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.TLS != nil && cfg.CertificateFingerprint != "" {
+		return Config{}, fmt.Errorf(
+			"%q config value must not be set when TLS config values are provided",
+			ConfigKeyCertificateFingerprint,
+		)
+	}
+
+	// Proxy
+	if cfg.ProxyURL, err = parseProxyURLConfigValue(cfgRaw); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.NoProxy = splitConfigList(cfgRaw[ConfigKeyNoProxy]); len(cfg.NoProxy) > 0 && cfg.ProxyURL == "" {
+		return Config{}, fmt.Errorf("%q config value must be set when %q is provided", ConfigKeyProxyURL, ConfigKeyNoProxy)
+	}
+
+	// Headers
+	if cfg.Header, err = parseHeaders(cfgRaw[ConfigKeyHeaders]); err != nil {
+		return Config{}, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyHeaders, err)
+	}
+
+	// AWS Signature Version 4
+	if cfg.Signer, err = parseAWSSigV4ConfigValues(cfgRaw, cfg); err != nil {
+		return Config{}, err
+	}
+
+	// Metrics listener
+	if cfg.MetricsAddress = cfgRaw[ConfigKeyMetricsAddress]; cfg.MetricsAddress != "" {
+		if _, _, err := net.SplitHostPort(cfg.MetricsAddress); err != nil {
+			return Config{}, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyMetricsAddress, err)
+		}
+	}
+
+	// Content hash IDs
+	if cfg.ContentHashID, err = parseBoolConfigValue(cfgRaw, ConfigKeyContentHashID); err != nil {
+		return Config{}, err
+	}
+
+	cfg.ContentHashFields = splitConfigList(cfgRaw[ConfigKeyContentHashFields])
+
+	if len(cfg.ContentHashFields) > 0 && !cfg.ContentHashID {
+		return Config{}, fmt.Errorf("%q config value must be enabled when %q is provided", ConfigKeyContentHashID, ConfigKeyContentHashFields)
+	}
+
+	// Values supported only by some versions are validated by Open for automatic version
+	if cfg.Version != elasticsearch.VersionAuto {
+		if err := cfg.checkVersionSupport(); err != nil {
+			return Config{}, err
+		}
+	}
+
 	return cfg, nil
 }
 
+var (
+	// timeUnitsPattern matches Elasticsearch time units, e.g. `7d`
+	timeUnitsPattern = regexp.MustCompile(`^[0-9]+(d|h|m|s|ms|micros|nanos)$`)
+
+	// byteSizeUnitsPattern matches Elasticsearch byte size units, e.g. `50gb`
+	byteSizeUnitsPattern = regexp.MustCompile(`^[0-9]+(b|kb|mb|gb|tb|pb)$`)
+)
+
 func requiredConfigErr(name string) error {
 	return fmt.Errorf("%q config value must be set", name)
 }
@@ -175,6 +631,37 @@ func parseBulkSizeConfigValue(cfgRaw map[string]string) (uint64, error) {
 	return bulkSizeParsed, nil
 }
 
+func parseBulkMaxBytesConfigValue(cfgRaw map[string]string) (uint64, error) {
+	bulkMaxBytes, ok := cfgRaw[ConfigKeyBulkMaxBytes]
+	if !ok || bulkMaxBytes == "" {
+		return 0, nil
+	}
+
+	bulkMaxBytesParsed, err := strconv.ParseUint(bulkMaxBytes, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyBulkMaxBytes, err)
+	}
+
+	return bulkMaxBytesParsed, nil
+}
+
+func parseBulkWorkersConfigValue(cfgRaw map[string]string) (uint8, error) {
+	bulkWorkers, ok := cfgRaw[ConfigKeyBulkWorkers]
+	if !ok || bulkWorkers == "" {
+		return 1, nil
+	}
+
+	bulkWorkersParsed, err := strconv.ParseUint(bulkWorkers, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyBulkWorkers, err)
+	}
+	if bulkWorkersParsed <= 0 {
+		return 0, fmt.Errorf("failed to parse %q config value: value must be greater than 0", ConfigKeyBulkWorkers)
+	}
+
+	return uint8(bulkWorkersParsed), nil
+}
+
 func parseRetriesConfigValue(cfgRaw map[string]string) (uint8, error) {
 	retries, ok := cfgRaw[ConfigKeyRetries]
 	if !ok || retries == "" {
@@ -188,3 +675,577 @@ func parseRetriesConfigValue(cfgRaw map[string]string) (uint8, error) {
 
 	return uint8(retriesParsed), nil
 }
+
+func parseDurationConfigValue(cfgRaw map[string]string, key string, defaultValue time.Duration) (time.Duration, error) {
+	duration, ok := cfgRaw[key]
+	if !ok || duration == "" {
+		return defaultValue, nil
+	}
+
+	durationParsed, err := time.ParseDuration(duration)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %q config value: %w", key, err)
+	}
+	if durationParsed < 0 {
+		return 0, fmt.Errorf("failed to parse %q config value: value must not be negative", key)
+	}
+
+	return durationParsed, nil
+}
+
+func parseDrainTimeoutConfigValue(cfgRaw map[string]string) (time.Duration, error) {
+	timeout, err := parseDurationConfigValue(cfgRaw, ConfigKeyDrainTimeout, 30*time.Second)
+	if err != nil {
+		return 0, err
+	}
+
+	// Zero timeout would expire before anything is flushed, nacking all buffered Records
+	if timeout == 0 {
+		return 0, fmt.Errorf("failed to parse %q config value: value must be greater than 0", ConfigKeyDrainTimeout)
+	}
+
+	return timeout, nil
+}
+
+func parseRetryMultiplierConfigValue(cfgRaw map[string]string) (float64, error) {
+	multiplier, ok := cfgRaw[ConfigKeyRetryMultiplier]
+	if !ok || multiplier == "" {
+		return 2, nil
+	}
+
+	multiplierParsed, err := strconv.ParseFloat(multiplier, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyRetryMultiplier, err)
+	}
+	if multiplierParsed < 1 {
+		return 0, fmt.Errorf("failed to parse %q config value: value must not be less than 1", ConfigKeyRetryMultiplier)
+	}
+
+	return multiplierParsed, nil
+}
+
+func parseRetryJitterConfigValue(cfgRaw map[string]string) (float64, error) {
+	jitter, ok := cfgRaw[ConfigKeyRetryJitter]
+	if !ok || jitter == "" {
+		return 0.2, nil
+	}
+
+	jitterParsed, err := strconv.ParseFloat(jitter, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyRetryJitter, err)
+	}
+	if jitterParsed < 0 || jitterParsed > 1 {
+		return 0, fmt.Errorf("failed to parse %q config value: value must be between 0 and 1", ConfigKeyRetryJitter)
+	}
+
+	return jitterParsed, nil
+}
+
+func parseFailurePoliciesConfigValue(cfgRaw map[string]string) (failurePolicies, error) {
+	policies := make(failurePolicies, len(defaultFailurePolicies))
+
+	for key, policy := range defaultFailurePolicies {
+		policies[key] = policy
+	}
+
+	// Entries from the config override the defaults
+	configPolicies, err := parseFailurePolicies(cfgRaw[ConfigKeyFailurePolicies])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyFailurePolicies, err)
+	}
+
+	for key, policy := range configPolicies {
+		policies[key] = policy
+	}
+
+	return policies, nil
+}
+
+// parseJSONConfigValue returns JSON object provided either inline or as a path to the file containing it.
+func parseJSONConfigValue(cfgRaw map[string]string, key, fileKey string) ([]byte, error) {
+	value, file := cfgRaw[key], cfgRaw[fileKey]
+
+	if value != "" && file != "" {
+		return nil, fmt.Errorf("%q config value must not be set when %q is provided", fileKey, key)
+	}
+
+	contents := []byte(value)
+
+	if file != "" {
+		var err error
+
+		key = fileKey
+
+		if contents, err = os.ReadFile(file); err != nil {
+			return nil, fmt.Errorf("failed to read %q config value: %w", key, err)
+		}
+	}
+
+	if len(contents) == 0 {
+		return nil, nil
+	}
+
+	// Values are sent as-is, so they are only checked to be a JSON object
+	var contentsParsed map[string]interface{}
+	if err := json.Unmarshal(contents, &contentsParsed); err != nil {
+		return nil, fmt.Errorf("failed to parse %q config value: %w", key, err)
+	}
+
+	return contents, nil
+}
+
+func parseComponentTemplatesConfigValue(cfgRaw map[string]string) (map[string]json.RawMessage, error) {
+	componentTemplates, err := parseJSONConfigValue(cfgRaw, ConfigKeyComponentTemplates, ConfigKeyComponentTemplatesFile)
+	if err != nil || componentTemplates == nil {
+		return nil, err
+	}
+
+	var componentTemplatesParsed map[string]map[string]interface{}
+	if err := json.Unmarshal(componentTemplates, &componentTemplatesParsed); err != nil {
+		return nil, fmt.Errorf("failed to parse %q config value: value must map template names to their bodies", ConfigKeyComponentTemplates)
+	}
+
+	var componentTemplatesRaw map[string]json.RawMessage
+	if err := json.Unmarshal(componentTemplates, &componentTemplatesRaw); err != nil {
+		return nil, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyComponentTemplates, err)
+	}
+
+	return componentTemplatesRaw, nil
+}
+
+func parseTemplateVersionConfigValue(cfgRaw map[string]string) (uint64, error) {
+	templateVersion, ok := cfgRaw[ConfigKeyTemplateVersion]
+	if !ok || templateVersion == "" {
+		return 1, nil
+	}
+
+	templateVersionParsed, err := strconv.ParseUint(templateVersion, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyTemplateVersion, err)
+	}
+
+	return templateVersionParsed, nil
+}
+
+func parseILMConfigValues(cfgRaw map[string]string, cfg *Config) (err error) {
+	if cfg.ILMPolicy == "" {
+		for _, key := range []string{
+			ConfigKeyILMRolloverMaxAge,
+			ConfigKeyILMRolloverMaxSize,
+			ConfigKeyILMRolloverMaxDocs,
+			ConfigKeyILMWarmMinAge,
+			ConfigKeyILMDeleteMinAge,
+		} {
+			if cfgRaw[key] != "" {
+				return fmt.Errorf("%q config value must be set when %q is provided", ConfigKeyILMPolicy, key)
+			}
+		}
+
+		return nil
+	}
+
+	if cfg.ILMRolloverMaxAge, err = parseUnitsConfigValue(cfgRaw, ConfigKeyILMRolloverMaxAge, timeUnitsPattern); err != nil {
+		return err
+	}
+
+	if cfg.ILMRolloverMaxSize, err = parseUnitsConfigValue(cfgRaw, ConfigKeyILMRolloverMaxSize, byteSizeUnitsPattern); err != nil {
+		return err
+	}
+
+	if maxDocs := cfgRaw[ConfigKeyILMRolloverMaxDocs]; maxDocs != "" {
+		if cfg.ILMRolloverMaxDocs, err = strconv.ParseUint(maxDocs, 10, 64); err != nil {
+			return fmt.Errorf("failed to parse %q config value: %w", ConfigKeyILMRolloverMaxDocs, err)
+		}
+	}
+
+	// Rollover requires at least one condition
+	if cfg.ILMRolloverMaxAge == "" && cfg.ILMRolloverMaxSize == "" && cfg.ILMRolloverMaxDocs == 0 {
+		cfg.ILMRolloverMaxAge = "30d"
+		cfg.ILMRolloverMaxSize = "50gb"
+	}
+
+	if cfg.ILMWarmMinAge, err = parseUnitsConfigValue(cfgRaw, ConfigKeyILMWarmMinAge, timeUnitsPattern); err != nil {
+		return err
+	}
+
+	if cfg.ILMDeleteMinAge, err = parseUnitsConfigValue(cfgRaw, ConfigKeyILMDeleteMinAge, timeUnitsPattern); err != nil {
+		return err
+	}
+
+	// Indices created by rollover get the policy from the index template,
+	// which is prepared once the version of the server is detected for automatic version
+	if cfg.IndexTemplate == nil && cfg.Version != elasticsearch.VersionAuto {
+		if cfg.IndexTemplate, err = json.Marshal(cfg.lifecycleIndexTemplate()); err != nil {
+			return fmt.Errorf("failed to prepare index template: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func parseUnitsConfigValue(cfgRaw map[string]string, key string, pattern *regexp.Regexp) (string, error) {
+	value := cfgRaw[key]
+
+	if value != "" && !pattern.MatchString(value) {
+		return "", fmt.Errorf("failed to parse %q config value: %q is not a valid value", key, value)
+	}
+
+	return value, nil
+}
+
+func parseMappingInferenceSamplesConfigValue(cfgRaw map[string]string) (uint64, error) {
+	samples, ok := cfgRaw[ConfigKeyMappingInferenceSamples]
+	if !ok || samples == "" {
+		return 0, nil
+	}
+
+	samplesParsed, err := strconv.ParseUint(samples, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyMappingInferenceSamples, err)
+	}
+
+	// The index is created either from the configuration or from inferred mapping
+	if samplesParsed > 0 {
+		for _, key := range []string{ConfigKeyIndexSettings, ConfigKeyIndexSettingsFile, ConfigKeyILMPolicy} {
+			if cfgRaw[key] != "" {
+				return 0, fmt.Errorf("%q config value must not be set when %q is provided", ConfigKeyMappingInferenceSamples, key)
+			}
+		}
+	}
+
+	return samplesParsed, nil
+}
+
+func parseFieldTransformationsConfigValues(cfgRaw map[string]string) (_ fieldTransformations, err error) {
+	transformations := fieldTransformations{
+		Nesting: cfgRaw[ConfigKeyFieldsNesting],
+		Include: splitConfigList(cfgRaw[ConfigKeyFieldsInclude]),
+		Exclude: splitConfigList(cfgRaw[ConfigKeyFieldsExclude]),
+	}
+
+	if transformations.Nesting != "" &&
+		transformations.Nesting != fieldsNestingFlatten &&
+		transformations.Nesting != fieldsNestingExpand {
+		return fieldTransformations{}, fmt.Errorf(
+			"%q config value must be one of [%s], %s provided",
+			ConfigKeyFieldsNesting,
+			strings.Join([]string{fieldsNestingFlatten, fieldsNestingExpand}, ", "),
+			transformations.Nesting,
+		)
+	}
+
+	if transformations.Rename, err = parseFieldRenames(cfgRaw[ConfigKeyFieldsRename]); err != nil {
+		return fieldTransformations{}, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyFieldsRename, err)
+	}
+
+	if transformations.Constants, err = parseFieldConstants(cfgRaw[ConfigKeyFieldsConstant]); err != nil {
+		return fieldTransformations{}, fmt.Errorf("failed to parse %q config value: %w", ConfigKeyFieldsConstant, err)
+	}
+
+	return transformations, nil
+}
+
+func parseBoolConfigValue(cfgRaw map[string]string, key string) (bool, error) {
+	value, ok := cfgRaw[key]
+	if !ok || value == "" {
+		return false, nil
+	}
+
+	valueParsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %q config value: %w", key, err)
+	}
+
+	return valueParsed, nil
+}
+
+func parseCompressionConfigValue(cfgRaw map[string]string) (internal.Compression, error) {
+	compression, ok := cfgRaw[ConfigKeyCompression]
+	if !ok || compression == "" {
+		return internal.CompressionNone, nil
+	}
+
+	if compression != internal.CompressionNone && compression != internal.CompressionGzip {
+		return "", fmt.Errorf(
+			"%q config value must be one of [%s], %s provided",
+			ConfigKeyCompression,
+			strings.Join([]string{internal.CompressionNone, internal.CompressionGzip}, ", "),
+			compression,
+		)
+	}
+
+	return compression, nil
+}
+
+// tlsVersions maps supported values of the minimum TLS version to their identifiers.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// parseTLSConfigValues returns TLS configuration of the connection with Elasticsearch,
+// or nil when none of TLS config values were provided.
+// Certificates are loaded here, so invalid files fail the configuration of the connector.
+func parseTLSConfigValues(cfgRaw map[string]string) (*tls.Config, error) {
+	var (
+		caCertFile     = cfgRaw[ConfigKeyTLSCACertFile]
+		clientCertFile = cfgRaw[ConfigKeyTLSClientCertFile]
+		clientKeyFile  = cfgRaw[ConfigKeyTLSClientKeyFile]
+		minVersion     = cfgRaw[ConfigKeyTLSMinVersion]
+	)
+
+	insecureSkipVerify, err := parseBoolConfigValue(cfgRaw, ConfigKeyTLSInsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	if caCertFile == "" && clientCertFile == "" && clientKeyFile == "" && minVersion == "" && !insecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify, //nolint:gosec // verification is disabled only on demand
+	}
+
+	// Certificate authorities
+	if caCertFile != "" {
+		caCert, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q config value: %w", ConfigKeyTLSCACertFile, err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse %q config value: no PEM encoded certificates found", ConfigKeyTLSCACertFile)
+		}
+	}
+
+	// Client certificate
+	if clientCertFile != "" && clientKeyFile == "" {
+		return nil, fmt.Errorf("%q config value must be set when %q is provided", ConfigKeyTLSClientKeyFile, ConfigKeyTLSClientCertFile)
+	}
+
+	if clientKeyFile != "" && clientCertFile == "" {
+		return nil, fmt.Errorf("%q config value must be set when %q is provided", ConfigKeyTLSClientCertFile, ConfigKeyTLSClientKeyFile)
+	}
+
+	if clientCertFile != "" {
+		clientCert, err := os.ReadFile(clientCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q config value: %w", ConfigKeyTLSClientCertFile, err)
+		}
+
+		clientKey, err := os.ReadFile(clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q config value: %w", ConfigKeyTLSClientKeyFile, err)
+		}
+
+		certificate, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to parse %q and %q config values: %w",
+				ConfigKeyTLSClientCertFile,
+				ConfigKeyTLSClientKeyFile,
+				err,
+			)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	// Minimum version
+	if minVersion != "" {
+		version, ok := tlsVersions[minVersion]
+		if !ok {
+			return nil, fmt.Errorf(
+				"%q config value must be one of [1.0, 1.1, 1.2, 1.3], %s provided",
+				ConfigKeyTLSMinVersion,
+				minVersion,
+			)
+		}
+
+		tlsConfig.MinVersion = version
+	}
+
+	return tlsConfig, nil
+}
+
+func parseProxyURLConfigValue(cfgRaw map[string]string) (string, error) {
+	proxyURL, ok := cfgRaw[ConfigKeyProxyURL]
+	if !ok || proxyURL == "" {
+		return "", nil
+	}
+
+	proxyURLParsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %q config value: %w", ConfigKeyProxyURL, err)
+	}
+
+	if proxyURLParsed.Scheme != "http" && proxyURLParsed.Scheme != "https" && proxyURLParsed.Scheme != "socks5" {
+		return "", fmt.Errorf("failed to parse %q config value: scheme must be one of [http, https, socks5]", ConfigKeyProxyURL)
+	}
+
+	if proxyURLParsed.Host == "" {
+		return "", fmt.Errorf("failed to parse %q config value: host must be set", ConfigKeyProxyURL)
+	}
+
+	return proxyURL, nil
+}
+
+// parseHeaders parses comma separated list of `name:value` entries.
+// Header values are never included in errors, as they may contain secrets.
+func parseHeaders(value string) (http.Header, error) {
+	entries := splitConfigList(value)
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	header := make(http.Header, len(entries))
+
+	for n, entry := range entries {
+		name, headerValue, ok := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+
+		if !ok || !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid entry #%d, expected format is name:value", n+1)
+		}
+
+		if headerValue = strings.TrimSpace(headerValue); !httpguts.ValidHeaderFieldValue(headerValue) {
+			return nil, fmt.Errorf("invalid value of %q header", name)
+		}
+
+		header.Add(name, headerValue)
+	}
+
+	return header, nil
+}
+
+// Below is a list of all supported sources of AWS credentials.
+const (
+	awsCredentialsStatic      = "static"
+	awsCredentialsEnvironment = "environment"
+	awsCredentialsWebIdentity = "webIdentity"
+)
+
+const (
+	defaultAWSService         = "es"
+	defaultAWSRoleSessionName = "conduit-connector-elasticsearch"
+)
+
+// parseAWSSigV4ConfigValues returns the signer of requests to Amazon OpenSearch Service,
+// or nil when signing is not enabled.
+func parseAWSSigV4ConfigValues(cfgRaw map[string]string, cfg Config) (*sigv4.Signer, error) {
+	enabled, err := parseBoolConfigValue(cfgRaw, ConfigKeyAWSSigV4)
+	if err != nil {
+		return nil, err
+	}
+
+	if !enabled {
+		return nil, nil
+	}
+
+	// Requests are authenticated with the signature only
+	for _, authentication := range []struct {
+		key   string
+		value string
+	}{
+		{key: ConfigKeyUsername, value: cfg.Username},
+		{key: ConfigKeyAPIKey, value: cfg.APIKey},
+		{key: ConfigKeyServiceToken, value: cfg.ServiceToken},
+		{key: ConfigKeyBearerToken, value: cfg.BearerToken},
+		{key: ConfigKeyCertificateFingerprint, value: cfg.CertificateFingerprint},
+	} {
+		if authentication.value != "" {
+			return nil, fmt.Errorf("%q config value must not be set when %q is provided", authentication.key, ConfigKeyAWSSigV4)
+		}
+	}
+
+	signer := sigv4.Signer{
+		Region:  cfgRaw[ConfigKeyAWSRegion],
+		Service: cfgRaw[ConfigKeyAWSService],
+	}
+
+	if signer.Region == "" {
+		return nil, fmt.Errorf("%q config value must be set when %q is provided", ConfigKeyAWSRegion, ConfigKeyAWSSigV4)
+	}
+
+	if signer.Service == "" {
+		signer.Service = defaultAWSService
+	}
+
+	switch source := cfgRaw[ConfigKeyAWSCredentials]; source {
+	case awsCredentialsStatic:
+		credentials := sigv4.StaticCredentials{
+			AccessKeyID:     cfgRaw[ConfigKeyAWSAccessKeyID],
+			SecretAccessKey: cfgRaw[ConfigKeyAWSSecretAccessKey],
+			SessionToken:    cfgRaw[ConfigKeyAWSSessionToken],
+		}
+
+		if credentials.AccessKeyID == "" {
+			return nil, fmt.Errorf("%q config value must be set when %q is %s", ConfigKeyAWSAccessKeyID, ConfigKeyAWSCredentials, source)
+		}
+
+		if credentials.SecretAccessKey == "" {
+			return nil, fmt.Errorf("%q config value must be set when %q is %s", ConfigKeyAWSSecretAccessKey, ConfigKeyAWSCredentials, source)
+		}
+
+		signer.Credentials = credentials
+
+	case awsCredentialsEnvironment, "":
+		signer.Credentials = sigv4.EnvironmentCredentials{}
+
+	case awsCredentialsWebIdentity:
+		credentials := &sigv4.WebIdentityCredentials{
+			RoleARN:     firstConfigValue(cfgRaw[ConfigKeyAWSRoleARN], os.Getenv("AWS_ROLE_ARN")),
+			TokenFile:   firstConfigValue(cfgRaw[ConfigKeyAWSWebIdentityTokenFile], os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")),
+			SessionName: firstConfigValue(cfgRaw[ConfigKeyAWSRoleSessionName], defaultAWSRoleSessionName),
+			Endpoint:    awsSTSEndpoint(signer.Region),
+		}
+
+		if credentials.RoleARN == "" {
+			return nil, fmt.Errorf("%q config value must be set when %q is %s", ConfigKeyAWSRoleARN, ConfigKeyAWSCredentials, source)
+		}
+
+		if credentials.TokenFile == "" {
+			return nil, fmt.Errorf("%q config value must be set when %q is %s", ConfigKeyAWSWebIdentityTokenFile, ConfigKeyAWSCredentials, source)
+		}
+
+		if _, err := os.ReadFile(credentials.TokenFile); err != nil {
+			return nil, fmt.Errorf("failed to read %q config value: %w", ConfigKeyAWSWebIdentityTokenFile, err)
+		}
+
+		signer.Credentials = credentials
+
+	default:
+		return nil, fmt.Errorf(
+			"%q config value must be one of [%s], %s provided",
+			ConfigKeyAWSCredentials,
+			strings.Join([]string{awsCredentialsStatic, awsCredentialsEnvironment, awsCredentialsWebIdentity}, ", "),
+			source,
+		)
+	}
+
+	return &signer, nil
+}
+
+// awsSTSEndpoint returns the regional endpoint of AWS Security Token Service.
+func awsSTSEndpoint(region string) string {
+	if strings.HasPrefix(region, "cn-") {
+		return fmt.Sprintf("https://sts.%s.amazonaws.com.cn/", region)
+	}
+
+	return fmt.Sprintf("https://sts.%s.amazonaws.com/", region)
+}
+
+func firstConfigValue(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
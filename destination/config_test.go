@@ -15,11 +15,27 @@
 package destination
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jaswdr/faker"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
 	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
 	"github.com/stretchr/testify/require"
 )
 
@@ -41,12 +57,16 @@ func TestParseConfig(t *testing.T) {
 		{
 			name: "Version is unsupported",
 			error: fmt.Sprintf(
-				"%q config value must be one of [%s, %s, %s, %s], invalid-version provided",
+				"%q config value must be one of [%s, %s, %s, %s, %s, %s, %s, %s], invalid-version provided",
 				ConfigKeyVersion,
 				elasticsearch.Version5,
 				elasticsearch.Version6,
 				elasticsearch.Version7,
 				elasticsearch.Version8,
+				elasticsearch.VersionOpenSearch1,
+				elasticsearch.VersionOpenSearch2,
+				elasticsearch.VersionGeneric,
+				elasticsearch.VersionAuto,
 			),
 			cfg: map[string]string{
 				ConfigKeyVersion: "invalid-version",
@@ -80,6 +100,17 @@ func TestParseConfig(t *testing.T) {
 				"nonExistentKey": "value",
 			},
 		},
+		{
+			name:  "Dead Letter Index is the same as Index",
+			error: fmt.Sprintf("%q config value must be different than %q", ConfigKeyDeadLetterIndex, ConfigKeyIndex),
+			cfg: map[string]string{
+				ConfigKeyVersion:         elasticsearch.Version8,
+				ConfigKeyHost:            fakerInstance.Internet().URL(),
+				ConfigKeyIndex:           "users",
+				ConfigKeyDeadLetterIndex: "users",
+				"nonExistentKey":         "value",
+			},
+		},
 		{
 			name:  "Bulk Size is empty",
 			error: fmt.Sprintf("%q config value must be set", ConfigKeyBulkSize),
@@ -123,6 +154,42 @@ func TestParseConfig(t *testing.T) {
 				"nonExistentKey":  "value",
 			},
 		},
+		{
+			name:  "Bulk Max Bytes is negative",
+			error: fmt.Sprintf(`failed to parse %q config value: strconv.ParseUint: parsing "-1": invalid syntax`, ConfigKeyBulkMaxBytes),
+			cfg: map[string]string{
+				ConfigKeyVersion:      elasticsearch.Version8,
+				ConfigKeyHost:         fakerInstance.Internet().URL(),
+				ConfigKeyIndex:        fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:     "1",
+				ConfigKeyBulkMaxBytes: "-1",
+				"nonExistentKey":      "value",
+			},
+		},
+		{
+			name:  "Bulk Workers is less than 1",
+			error: fmt.Sprintf("failed to parse %q config value: value must be greater than 0", ConfigKeyBulkWorkers),
+			cfg: map[string]string{
+				ConfigKeyVersion:     elasticsearch.Version8,
+				ConfigKeyHost:        fakerInstance.Internet().URL(),
+				ConfigKeyIndex:       fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:    "1",
+				ConfigKeyBulkWorkers: "0",
+				"nonExistentKey":     "value",
+			},
+		},
+		{
+			name:  "Bulk Workers is greater than 255",
+			error: fmt.Sprintf(`failed to parse %q config value: strconv.ParseUint: parsing "256": value out of range`, ConfigKeyBulkWorkers),
+			cfg: map[string]string{
+				ConfigKeyVersion:     elasticsearch.Version8,
+				ConfigKeyHost:        fakerInstance.Internet().URL(),
+				ConfigKeyIndex:       fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:    "1",
+				ConfigKeyBulkWorkers: "256",
+				"nonExistentKey":     "value",
+			},
+		},
 		{
 			name:  "Retries is negative",
 			error: fmt.Sprintf(`failed to parse %q config value: strconv.ParseUint: parsing "-1": invalid syntax`, ConfigKeyRetries),
@@ -147,6 +214,455 @@ func TestParseConfig(t *testing.T) {
 				"nonExistentKey":  "value",
 			},
 		},
+		{
+			name:  "Retry Initial Delay is invalid",
+			error: fmt.Sprintf(`failed to parse %q config value: time: invalid duration "soon"`, ConfigKeyRetryInitialDelay),
+			cfg: map[string]string{
+				ConfigKeyVersion:           elasticsearch.Version8,
+				ConfigKeyHost:              fakerInstance.Internet().URL(),
+				ConfigKeyIndex:             fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:          "1",
+				ConfigKeyRetryInitialDelay: "soon",
+				"nonExistentKey":           "value",
+			},
+		},
+		{
+			name:  "Retry Max Delay is negative",
+			error: fmt.Sprintf("failed to parse %q config value: value must not be negative", ConfigKeyRetryMaxDelay),
+			cfg: map[string]string{
+				ConfigKeyVersion:       elasticsearch.Version8,
+				ConfigKeyHost:          fakerInstance.Internet().URL(),
+				ConfigKeyIndex:         fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:      "1",
+				ConfigKeyRetryMaxDelay: "-1s",
+				"nonExistentKey":       "value",
+			},
+		},
+		{
+			name:  "Drain Timeout is invalid",
+			error: fmt.Sprintf("failed to parse %q config value: time: invalid duration \"forever\"", ConfigKeyDrainTimeout),
+			cfg: map[string]string{
+				ConfigKeyVersion:      elasticsearch.Version8,
+				ConfigKeyHost:         fakerInstance.Internet().URL(),
+				ConfigKeyIndex:        fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:     "1",
+				ConfigKeyDrainTimeout: "forever",
+				"nonExistentKey":      "value",
+			},
+		},
+		{
+			name:  "Drain Timeout is zero",
+			error: fmt.Sprintf("failed to parse %q config value: value must be greater than 0", ConfigKeyDrainTimeout),
+			cfg: map[string]string{
+				ConfigKeyVersion:      elasticsearch.Version8,
+				ConfigKeyHost:         fakerInstance.Internet().URL(),
+				ConfigKeyIndex:        fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:     "1",
+				ConfigKeyDrainTimeout: "0s",
+				"nonExistentKey":      "value",
+			},
+		},
+		{
+			name:  "Retry Multiplier is less than 1",
+			error: fmt.Sprintf("failed to parse %q config value: value must not be less than 1", ConfigKeyRetryMultiplier),
+			cfg: map[string]string{
+				ConfigKeyVersion:         elasticsearch.Version8,
+				ConfigKeyHost:            fakerInstance.Internet().URL(),
+				ConfigKeyIndex:           fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:        "1",
+				ConfigKeyRetryMultiplier: "0.5",
+				"nonExistentKey":         "value",
+			},
+		},
+		{
+			name:  "Retry Jitter is greater than 1",
+			error: fmt.Sprintf("failed to parse %q config value: value must be between 0 and 1", ConfigKeyRetryJitter),
+			cfg: map[string]string{
+				ConfigKeyVersion:     elasticsearch.Version8,
+				ConfigKeyHost:        fakerInstance.Internet().URL(),
+				ConfigKeyIndex:       fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:    "1",
+				ConfigKeyRetryJitter: "1.5",
+				"nonExistentKey":     "value",
+			},
+		},
+		{
+			name:  "Failure Policies are invalid",
+			error: fmt.Sprintf(`failed to parse %q config value: invalid entry "404", expected format is [action:]status|errorType=policy`, ConfigKeyFailurePolicies),
+			cfg: map[string]string{
+				ConfigKeyVersion:         elasticsearch.Version8,
+				ConfigKeyHost:            fakerInstance.Internet().URL(),
+				ConfigKeyIndex:           fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:        "1",
+				ConfigKeyFailurePolicies: "404",
+				"nonExistentKey":         "value",
+			},
+		},
+		{
+			name:  "Index Settings are not valid JSON",
+			error: fmt.Sprintf("failed to parse %q config value: unexpected end of JSON input", ConfigKeyIndexSettings),
+			cfg: map[string]string{
+				ConfigKeyVersion:       elasticsearch.Version8,
+				ConfigKeyHost:          fakerInstance.Internet().URL(),
+				ConfigKeyIndex:         fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:      "1",
+				ConfigKeyIndexSettings: `{"mappings":`,
+				"nonExistentKey":       "value",
+			},
+		},
+		{
+			name:  "Index Settings and Index Settings File are both provided",
+			error: fmt.Sprintf("%q config value must not be set when %q is provided", ConfigKeyIndexSettingsFile, ConfigKeyIndexSettings),
+			cfg: map[string]string{
+				ConfigKeyVersion:           elasticsearch.Version8,
+				ConfigKeyHost:              fakerInstance.Internet().URL(),
+				ConfigKeyIndex:             fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:          "1",
+				ConfigKeyIndexSettings:     `{}`,
+				ConfigKeyIndexSettingsFile: "settings.json",
+				"nonExistentKey":           "value",
+			},
+		},
+		{
+			name:  "Index Settings File does not exist",
+			error: fmt.Sprintf("failed to read %q config value: open non-existent.json: no such file or directory", ConfigKeyIndexSettingsFile),
+			cfg: map[string]string{
+				ConfigKeyVersion:           elasticsearch.Version8,
+				ConfigKeyHost:              fakerInstance.Internet().URL(),
+				ConfigKeyIndex:             fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:          "1",
+				ConfigKeyIndexSettingsFile: "non-existent.json",
+				"nonExistentKey":           "value",
+			},
+		},
+		{
+			name:  "Component Templates are not a map of template bodies",
+			error: fmt.Sprintf("failed to parse %q config value: value must map template names to their bodies", ConfigKeyComponentTemplates),
+			cfg: map[string]string{
+				ConfigKeyVersion:            elasticsearch.Version8,
+				ConfigKeyHost:               fakerInstance.Internet().URL(),
+				ConfigKeyIndex:              fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:           "1",
+				ConfigKeyComponentTemplates: `{"users-settings":"settings"}`,
+				"nonExistentKey":            "value",
+			},
+		},
+		{
+			name:  "Component Templates are provided for Version=6",
+			error: fmt.Sprintf("%q config value is supported only for versions 7 and 8", ConfigKeyComponentTemplates),
+			cfg: map[string]string{
+				ConfigKeyVersion:            elasticsearch.Version6,
+				ConfigKeyHost:               fakerInstance.Internet().URL(),
+				ConfigKeyIndex:              fakerInstance.Lorem().Word(),
+				ConfigKeyType:               fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:           "1",
+				ConfigKeyComponentTemplates: `{"users-settings":{"template":{}}}`,
+				"nonExistentKey":            "value",
+			},
+		},
+		{
+			name:  "API Key is provided for Version=opensearch2",
+			error: fmt.Sprintf("%q config value is not supported by OpenSearch", ConfigKeyAPIKey),
+			cfg: map[string]string{
+				ConfigKeyVersion:  elasticsearch.VersionOpenSearch2,
+				ConfigKeyHost:     fakerInstance.Internet().URL(),
+				ConfigKeyAPIKey:   fakerInstance.Internet().Password(),
+				ConfigKeyIndex:    fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize: "1",
+				"nonExistentKey":  "value",
+			},
+		},
+		{
+			name:  "Bearer Token is provided for Version=8",
+			error: fmt.Sprintf("%q config value is supported only for versions opensearch1 and opensearch2", ConfigKeyBearerToken),
+			cfg: map[string]string{
+				ConfigKeyVersion:     elasticsearch.Version8,
+				ConfigKeyHost:        fakerInstance.Internet().URL(),
+				ConfigKeyBearerToken: fakerInstance.Internet().Password(),
+				ConfigKeyIndex:       fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:    "1",
+				"nonExistentKey":     "value",
+			},
+		},
+		{
+			name:  "Sniff On Failure is enabled for Version=generic",
+			error: fmt.Sprintf("%q config value is not supported by version generic", ConfigKeySniffOnFailure),
+			cfg: map[string]string{
+				ConfigKeyVersion:        elasticsearch.VersionGeneric,
+				ConfigKeyHost:           fakerInstance.Internet().URL(),
+				ConfigKeyIndex:          fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:       "1",
+				ConfigKeySniffOnFailure: "true",
+				"nonExistentKey":        "value",
+			},
+		},
+		{
+			name:  "Template Version is invalid",
+			error: fmt.Sprintf(`failed to parse %q config value: strconv.ParseUint: parsing "-1": invalid syntax`, ConfigKeyTemplateVersion),
+			cfg: map[string]string{
+				ConfigKeyVersion:         elasticsearch.Version8,
+				ConfigKeyHost:            fakerInstance.Internet().URL(),
+				ConfigKeyIndex:           fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:        "1",
+				ConfigKeyTemplateVersion: "-1",
+				"nonExistentKey":         "value",
+			},
+		},
+		{
+			name:  "ILM Policy is provided for Version=opensearch1",
+			error: fmt.Sprintf("%q config value is not supported by OpenSearch", ConfigKeyILMPolicy),
+			cfg: map[string]string{
+				ConfigKeyVersion:   elasticsearch.VersionOpenSearch1,
+				ConfigKeyHost:      fakerInstance.Internet().URL(),
+				ConfigKeyIndex:     fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:  "1",
+				ConfigKeyILMPolicy: "policy",
+				"nonExistentKey":   "value",
+			},
+		},
+		{
+			name:  "ILM Policy is provided for Version=5",
+			error: fmt.Sprintf("%q config value is supported only for versions 6.6 and newer", ConfigKeyILMPolicy),
+			cfg: map[string]string{
+				ConfigKeyVersion:   elasticsearch.Version5,
+				ConfigKeyHost:      fakerInstance.Internet().URL(),
+				ConfigKeyIndex:     fakerInstance.Lorem().Word(),
+				ConfigKeyType:      fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:  "1",
+				ConfigKeyILMPolicy: "policy",
+				"nonExistentKey":   "value",
+			},
+		},
+		{
+			name:  "ILM Rollover Max Age is provided without ILM Policy",
+			error: fmt.Sprintf("%q config value must be set when %q is provided", ConfigKeyILMPolicy, ConfigKeyILMRolloverMaxAge),
+			cfg: map[string]string{
+				ConfigKeyVersion:           elasticsearch.Version8,
+				ConfigKeyHost:              fakerInstance.Internet().URL(),
+				ConfigKeyIndex:             fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:          "1",
+				ConfigKeyILMRolloverMaxAge: "1d",
+				"nonExistentKey":           "value",
+			},
+		},
+		{
+			name:  "ILM Rollover Max Size is invalid",
+			error: fmt.Sprintf(`failed to parse %q config value: "50 GB" is not a valid value`, ConfigKeyILMRolloverMaxSize),
+			cfg: map[string]string{
+				ConfigKeyVersion:            elasticsearch.Version8,
+				ConfigKeyHost:               fakerInstance.Internet().URL(),
+				ConfigKeyIndex:              fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:           "1",
+				ConfigKeyILMPolicy:          "policy",
+				ConfigKeyILMRolloverMaxSize: "50 GB",
+				"nonExistentKey":            "value",
+			},
+		},
+		{
+			name:  "ILM Delete Min Age is invalid",
+			error: fmt.Sprintf(`failed to parse %q config value: "1 month" is not a valid value`, ConfigKeyILMDeleteMinAge),
+			cfg: map[string]string{
+				ConfigKeyVersion:         elasticsearch.Version8,
+				ConfigKeyHost:            fakerInstance.Internet().URL(),
+				ConfigKeyIndex:           fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:        "1",
+				ConfigKeyILMPolicy:       "policy",
+				ConfigKeyILMDeleteMinAge: "1 month",
+				"nonExistentKey":         "value",
+			},
+		},
+		{
+			name:  "Mapping Inference Samples are provided together with Index Settings",
+			error: fmt.Sprintf("%q config value must not be set when %q is provided", ConfigKeyMappingInferenceSamples, ConfigKeyIndexSettings),
+			cfg: map[string]string{
+				ConfigKeyVersion:                 elasticsearch.Version8,
+				ConfigKeyHost:                    fakerInstance.Internet().URL(),
+				ConfigKeyIndex:                   fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:                "1",
+				ConfigKeyIndexSettings:           `{}`,
+				ConfigKeyMappingInferenceSamples: "100",
+				"nonExistentKey":                 "value",
+			},
+		},
+		{
+			name:  "Fields Nesting is invalid",
+			error: fmt.Sprintf("%q config value must be one of [flatten, expand], nested provided", ConfigKeyFieldsNesting),
+			cfg: map[string]string{
+				ConfigKeyVersion:       elasticsearch.Version8,
+				ConfigKeyHost:          fakerInstance.Internet().URL(),
+				ConfigKeyIndex:         fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:      "1",
+				ConfigKeyFieldsNesting: "nested",
+				"nonExistentKey":       "value",
+			},
+		},
+		{
+			name:  "Fields Rename is invalid",
+			error: fmt.Sprintf(`failed to parse %q config value: invalid entry "name", expected format is from:to`, ConfigKeyFieldsRename),
+			cfg: map[string]string{
+				ConfigKeyVersion:      elasticsearch.Version8,
+				ConfigKeyHost:         fakerInstance.Internet().URL(),
+				ConfigKeyIndex:        fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:     "1",
+				ConfigKeyFieldsRename: "name",
+				"nonExistentKey":      "value",
+			},
+		},
+		{
+			name:  "Fields Constant is invalid",
+			error: fmt.Sprintf(`failed to parse %q config value: invalid entry ":value", expected format is field:value`, ConfigKeyFieldsConstant),
+			cfg: map[string]string{
+				ConfigKeyVersion:        elasticsearch.Version8,
+				ConfigKeyHost:           fakerInstance.Internet().URL(),
+				ConfigKeyIndex:          fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:       "1",
+				ConfigKeyFieldsConstant: ":value",
+				"nonExistentKey":        "value",
+			},
+		},
+		{
+			name:  "Debezium Envelope is invalid",
+			error: fmt.Sprintf(`failed to parse %q config value: strconv.ParseBool: parsing "maybe": invalid syntax`, ConfigKeyDebeziumEnvelope),
+			cfg: map[string]string{
+				ConfigKeyVersion:          elasticsearch.Version8,
+				ConfigKeyHost:             fakerInstance.Internet().URL(),
+				ConfigKeyIndex:            fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:         "1",
+				ConfigKeyDebeziumEnvelope: "maybe",
+				"nonExistentKey":          "value",
+			},
+		},
+		{
+			name:  "Debezium Versioning is enabled without Debezium Envelope",
+			error: fmt.Sprintf("%q config value must be enabled when %q is provided", ConfigKeyDebeziumEnvelope, ConfigKeyDebeziumVersioning),
+			cfg: map[string]string{
+				ConfigKeyVersion:            elasticsearch.Version8,
+				ConfigKeyHost:               fakerInstance.Internet().URL(),
+				ConfigKeyIndex:              fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:           "1",
+				ConfigKeyDebeziumVersioning: "true",
+				"nonExistentKey":            "value",
+			},
+		},
+		{
+			name:  "Content Hash Fields are set without Content Hash ID",
+			error: fmt.Sprintf("%q config value must be enabled when %q is provided", ConfigKeyContentHashID, ConfigKeyContentHashFields),
+			cfg: map[string]string{
+				ConfigKeyVersion:           elasticsearch.Version8,
+				ConfigKeyHost:              fakerInstance.Internet().URL(),
+				ConfigKeyIndex:             fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:          "1",
+				ConfigKeyContentHashFields: "id",
+				"nonExistentKey":           "value",
+			},
+		},
+		{
+			name:  "Compression is invalid",
+			error: fmt.Sprintf("%q config value must be one of [none, gzip], zstd provided", ConfigKeyCompression),
+			cfg: map[string]string{
+				ConfigKeyVersion:     elasticsearch.Version8,
+				ConfigKeyHost:        fakerInstance.Internet().URL(),
+				ConfigKeyIndex:       fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:    "1",
+				ConfigKeyCompression: "zstd",
+				"nonExistentKey":     "value",
+			},
+		},
+		{
+			name:  "Host contains no entries",
+			error: fmt.Sprintf("%q config value must be set", ConfigKeyHost),
+			cfg: map[string]string{
+				ConfigKeyVersion: elasticsearch.Version8,
+				ConfigKeyHost:    " , ",
+				"nonExistentKey": "value",
+			},
+		},
+		{
+			name:  "Sniff On Start is enabled for Version=5",
+			error: fmt.Sprintf("%q config value is supported only for versions 6 and newer", ConfigKeySniffOnStart),
+			cfg: map[string]string{
+				ConfigKeyVersion:      elasticsearch.Version5,
+				ConfigKeyHost:         fakerInstance.Internet().URL(),
+				ConfigKeyIndex:        fakerInstance.Lorem().Word(),
+				ConfigKeyType:         fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:     "1",
+				ConfigKeySniffOnStart: "true",
+				"nonExistentKey":      "value",
+			},
+		},
+		{
+			name:  "Sniff On Failure is enabled for Version=5",
+			error: fmt.Sprintf("%q config value is supported only for versions 6 and newer", ConfigKeySniffOnFailure),
+			cfg: map[string]string{
+				ConfigKeyVersion:        elasticsearch.Version5,
+				ConfigKeyHost:           fakerInstance.Internet().URL(),
+				ConfigKeyIndex:          fakerInstance.Lorem().Word(),
+				ConfigKeyType:           fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:       "1",
+				ConfigKeySniffOnFailure: "true",
+				"nonExistentKey":        "value",
+			},
+		},
+		{
+			name:  "Proxy URL has unsupported scheme",
+			error: fmt.Sprintf("failed to parse %q config value: scheme must be one of [http, https, socks5]", ConfigKeyProxyURL),
+			cfg: map[string]string{
+				ConfigKeyVersion:  elasticsearch.Version8,
+				ConfigKeyHost:     fakerInstance.Internet().URL(),
+				ConfigKeyIndex:    fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize: "1",
+				ConfigKeyProxyURL: "ftp://proxy.local:3128",
+				"nonExistentKey":  "value",
+			},
+		},
+		{
+			name:  "Proxy URL has no host",
+			error: fmt.Sprintf("failed to parse %q config value: host must be set", ConfigKeyProxyURL),
+			cfg: map[string]string{
+				ConfigKeyVersion:  elasticsearch.Version8,
+				ConfigKeyHost:     fakerInstance.Internet().URL(),
+				ConfigKeyIndex:    fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize: "1",
+				ConfigKeyProxyURL: "http://",
+				"nonExistentKey":  "value",
+			},
+		},
+		{
+			name:  "No Proxy is provided but Proxy URL is empty",
+			error: fmt.Sprintf("%q config value must be set when %q is provided", ConfigKeyProxyURL, ConfigKeyNoProxy),
+			cfg: map[string]string{
+				ConfigKeyVersion:  elasticsearch.Version8,
+				ConfigKeyHost:     fakerInstance.Internet().URL(),
+				ConfigKeyIndex:    fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize: "1",
+				ConfigKeyNoProxy:  "localhost",
+				"nonExistentKey":  "value",
+			},
+		},
+		{
+			name:  "Headers contain invalid entry",
+			error: fmt.Sprintf("failed to parse %q config value: invalid entry #2, expected format is name:value", ConfigKeyHeaders),
+			cfg: map[string]string{
+				ConfigKeyVersion:  elasticsearch.Version8,
+				ConfigKeyHost:     fakerInstance.Internet().URL(),
+				ConfigKeyIndex:    fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize: "1",
+				ConfigKeyHeaders:  "X-Gateway-Key:secret,X Tenant:secret",
+				"nonExistentKey":  "value",
+			},
+		},
+		{
+			name:  "Metrics Address has no port",
+			error: fmt.Sprintf("failed to parse %q config value: address localhost: missing port in address", ConfigKeyMetricsAddress),
+			cfg: map[string]string{
+				ConfigKeyVersion:        elasticsearch.Version8,
+				ConfigKeyHost:           fakerInstance.Internet().URL(),
+				ConfigKeyIndex:          fakerInstance.Lorem().Word(),
+				ConfigKeyBulkSize:       "1",
+				ConfigKeyMetricsAddress: "localhost",
+				"nonExistentKey":        "value",
+			},
+		},
 	} {
 		t.Run(fmt.Sprintf("Fails when: %s", tt.name), func(t *testing.T) {
 			_, err := ParseConfig(tt.cfg)
@@ -194,7 +710,34 @@ func TestParseConfig(t *testing.T) {
 		require.Empty(t, "", config.APIKey)
 		require.Empty(t, "", config.ServiceToken)
 		require.Empty(t, "", config.CertificateFingerprint)
+		require.Equal(t, uint64(0), config.BulkMaxBytes)
+		require.Equal(t, uint8(1), config.BulkWorkers)
 		require.Equal(t, uint8(0), config.Retries)
+		require.Equal(t, 100*time.Millisecond, config.RetryInitialDelay)
+		require.Equal(t, 30*time.Second, config.RetryMaxDelay)
+		require.Equal(t, float64(2), config.RetryMultiplier)
+		require.Equal(t, 0.2, config.RetryJitter)
+		require.Equal(t, 30*time.Second, config.DrainTimeout)
+		require.Equal(t, defaultFailurePolicies, config.FailurePolicies)
+		require.Nil(t, config.IndexSettings)
+		require.Nil(t, config.IndexTemplate)
+		require.Equal(t, cfgRaw[ConfigKeyIndex], config.IndexTemplateName)
+		require.Nil(t, config.ComponentTemplates)
+		require.Equal(t, uint64(1), config.TemplateVersion)
+		require.Empty(t, config.ILMPolicy)
+		require.Equal(t, uint64(0), config.MappingInferenceSamples)
+		require.True(t, config.FieldTransformations.Empty())
+		require.False(t, config.DebeziumEnvelope)
+		require.False(t, config.DebeziumVersioning)
+		require.False(t, config.ContentHashID)
+		require.Nil(t, config.ContentHashFields)
+		require.Equal(t, internal.CompressionNone, config.Compression)
+		require.Equal(t, []string{cfgRaw[ConfigKeyHost]}, config.Hosts)
+		require.False(t, config.SniffOnStart)
+		require.False(t, config.SniffOnFailure)
+		require.Nil(t, config.GetProxy())
+		require.Nil(t, config.Header)
+		require.Empty(t, config.MetricsAddress)
 	})
 
 	t.Run("Returns config when all config values were provided", func(t *testing.T) {
@@ -204,6 +747,8 @@ func TestParseConfig(t *testing.T) {
 			ConfigKeyIndex:                  fakerInstance.Lorem().Word(),
 			ConfigKeyType:                   fakerInstance.Lorem().Word(),
 			ConfigKeyBulkSize:               fmt.Sprintf("%d", fakerInstance.Int32Between(1, 10_000)),
+			ConfigKeyBulkMaxBytes:           fmt.Sprintf("%d", fakerInstance.Int32Between(1, 100_000_000)),
+			ConfigKeyBulkWorkers:            fmt.Sprintf("%d", fakerInstance.Int32Between(1, 255)),
 			ConfigKeyUsername:               fakerInstance.Internet().Email(),
 			ConfigKeyPassword:               fakerInstance.Internet().Password(),
 			ConfigKeyCloudID:                fakerInstance.RandomStringWithLength(32),
@@ -211,6 +756,34 @@ func TestParseConfig(t *testing.T) {
 			ConfigKeyServiceToken:           fakerInstance.RandomStringWithLength(32),
 			ConfigKeyCertificateFingerprint: fakerInstance.Hash().SHA256(),
 			ConfigKeyRetries:                fmt.Sprintf("%d", fakerInstance.Int32Between(1, 255)),
+			ConfigKeyRetryInitialDelay:      "250ms",
+			ConfigKeyRetryMaxDelay:          "1m",
+			ConfigKeyRetryMultiplier:        "1.5",
+			ConfigKeyRetryJitter:            "0",
+			ConfigKeyDrainTimeout:           "5s",
+			ConfigKeyDeadLetterIndex:        fakerInstance.Lorem().Word() + "-dead-letters",
+			ConfigKeyFailurePolicies:        "version_conflict_engine_exception=fail,index:400=retry",
+			ConfigKeyIndexSettings:          `{"mappings":{"properties":{"name":{"type":"keyword"}}}}`,
+			ConfigKeyIndexTemplate:          `{"index_patterns":["users-*"],"composed_of":["users-settings"]}`,
+			ConfigKeyIndexTemplateName:      "users",
+			ConfigKeyComponentTemplates:     `{"users-settings":{"template":{"settings":{"number_of_shards":1}}}}`,
+			ConfigKeyTemplateVersion:        "5",
+			ConfigKeyFieldsNesting:          "flatten",
+			ConfigKeyFieldsInclude:          "id,name,address",
+			ConfigKeyFieldsExclude:          "address.zip",
+			ConfigKeyFieldsRename:           "name:fullName",
+			ConfigKeyFieldsConstant:         "source:conduit",
+			ConfigKeyDebeziumEnvelope:       "true",
+			ConfigKeyDebeziumVersioning:     "true",
+			ConfigKeyContentHashID:          "true",
+			ConfigKeyContentHashFields:      "id, address.city",
+			ConfigKeyCompression:            "gzip",
+			ConfigKeySniffOnStart:           "true",
+			ConfigKeySniffOnFailure:         "true",
+			ConfigKeyProxyURL:               "http://proxy.local:3128",
+			ConfigKeyNoProxy:                "localhost, .internal",
+			ConfigKeyHeaders:                "X-Gateway-Key:secret, X-Tenant:conduit",
+			ConfigKeyMetricsAddress:         "127.0.0.1:9464",
 			"nonExistentKey":                "value",
 		}
 
@@ -222,6 +795,8 @@ func TestParseConfig(t *testing.T) {
 		require.Equal(t, cfgRaw[ConfigKeyIndex], config.Index)
 		require.Equal(t, cfgRaw[ConfigKeyType], config.Type)
 		require.Equal(t, cfgRaw[ConfigKeyBulkSize], fmt.Sprintf("%d", config.BulkSize))
+		require.Equal(t, cfgRaw[ConfigKeyBulkMaxBytes], fmt.Sprintf("%d", config.BulkMaxBytes))
+		require.Equal(t, cfgRaw[ConfigKeyBulkWorkers], fmt.Sprintf("%d", config.BulkWorkers))
 		require.Equal(t, cfgRaw[ConfigKeyUsername], config.Username)
 		require.Equal(t, cfgRaw[ConfigKeyPassword], config.Password)
 		require.Equal(t, cfgRaw[ConfigKeyCloudID], config.CloudID)
@@ -229,6 +804,482 @@ func TestParseConfig(t *testing.T) {
 		require.Equal(t, cfgRaw[ConfigKeyServiceToken], config.ServiceToken)
 		require.Equal(t, cfgRaw[ConfigKeyCertificateFingerprint], config.CertificateFingerprint)
 		require.Equal(t, cfgRaw[ConfigKeyRetries], fmt.Sprintf("%d", config.Retries))
+		require.Equal(t, 250*time.Millisecond, config.RetryInitialDelay)
+		require.Equal(t, time.Minute, config.RetryMaxDelay)
+		require.Equal(t, 1.5, config.RetryMultiplier)
+		require.Equal(t, float64(0), config.RetryJitter)
+		require.Equal(t, 5*time.Second, config.DrainTimeout)
+		require.Equal(t, cfgRaw[ConfigKeyDeadLetterIndex], config.DeadLetterIndex)
+		require.Equal(t, failurePolicies{
+			"delete:404":                        failurePolicySuccess,
+			"version_conflict_engine_exception": failurePolicyFail,
+			"mapper_parsing_exception":          failurePolicyDeadLetter,
+			"429":                               failurePolicyRetry,
+			"index:400":                         failurePolicyRetry,
+		}, config.FailurePolicies)
+		require.Equal(t, cfgRaw[ConfigKeyIndexSettings], string(config.IndexSettings))
+		require.Equal(t, cfgRaw[ConfigKeyIndexTemplate], string(config.IndexTemplate))
+		require.Equal(t, cfgRaw[ConfigKeyIndexTemplateName], config.IndexTemplateName)
+		require.Equal(t, map[string]json.RawMessage{
+			"users-settings": json.RawMessage(`{"template":{"settings":{"number_of_shards":1}}}`),
+		}, config.ComponentTemplates)
+		require.Equal(t, uint64(5), config.TemplateVersion)
+		require.Equal(t, fieldTransformations{
+			Nesting:   fieldsNestingFlatten,
+			Include:   []string{"id", "name", "address"},
+			Exclude:   []string{"address.zip"},
+			Rename:    []fieldRename{{From: "name", To: "fullName"}},
+			Constants: []fieldConstant{{Field: "source", Value: "conduit"}},
+		}, config.FieldTransformations)
+		require.True(t, config.DebeziumEnvelope)
+		require.True(t, config.DebeziumVersioning)
+		require.True(t, config.ContentHashID)
+		require.Equal(t, []string{"id", "address.city"}, config.ContentHashFields)
+		require.Equal(t, internal.CompressionGzip, config.Compression)
+		require.True(t, config.SniffOnStart)
+		require.True(t, config.SniffOnFailure)
+		require.Equal(t, cfgRaw[ConfigKeyProxyURL], config.ProxyURL)
+		require.Equal(t, []string{"localhost", ".internal"}, config.NoProxy)
+		require.Equal(t, http.Header{
+			"X-Gateway-Key": []string{"secret"},
+			"X-Tenant":      []string{"conduit"},
+		}, config.Header)
+		require.Equal(t, cfgRaw[ConfigKeyMetricsAddress], config.MetricsAddress)
+	})
+
+	t.Run("Returns proxy which skips hosts from No Proxy", func(t *testing.T) {
+		config, err := ParseConfig(map[string]string{
+			ConfigKeyVersion:  elasticsearch.Version8,
+			ConfigKeyHost:     fakerInstance.Internet().URL(),
+			ConfigKeyIndex:    fakerInstance.Lorem().Word(),
+			ConfigKeyBulkSize: "1",
+			ConfigKeyProxyURL: "http://proxy.local:3128",
+			ConfigKeyNoProxy:  ".internal",
+		})
+		require.NoError(t, err)
+
+		proxy := config.GetProxy()
+
+		proxied, err := proxy(httptest.NewRequest(http.MethodPost, "https://elasticsearch.example.com:9200/_bulk", nil))
+		require.NoError(t, err)
+		require.Equal(t, "http://proxy.local:3128", proxied.String())
+
+		direct, err := proxy(httptest.NewRequest(http.MethodPost, "https://elasticsearch.internal:9200/_bulk", nil))
+		require.NoError(t, err)
+		require.Nil(t, direct)
+	})
+
+	t.Run("Returns config with all Hosts when comma separated list was provided", func(t *testing.T) {
+		config, err := ParseConfig(map[string]string{
+			ConfigKeyVersion:  elasticsearch.Version8,
+			ConfigKeyHost:     "http://127.0.0.1:9200, http://127.0.0.2:9200,",
+			ConfigKeyIndex:    fakerInstance.Lorem().Word(),
+			ConfigKeyBulkSize: "1",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://127.0.0.1:9200", "http://127.0.0.2:9200"}, config.Hosts)
+	})
+
+	t.Run("Returns config for OpenSearch with basic authentication and component templates", func(t *testing.T) {
+		config, err := ParseConfig(map[string]string{
+			ConfigKeyVersion:            elasticsearch.VersionOpenSearch2,
+			ConfigKeyHost:               "https://127.0.0.1:9200",
+			ConfigKeyUsername:           "admin",
+			ConfigKeyPassword:           "admin",
+			ConfigKeyIndex:              "users",
+			ConfigKeyBulkSize:           "1",
+			ConfigKeyComponentTemplates: `{"users-settings":{"template":{}}}`,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, elasticsearch.VersionOpenSearch2, config.Version)
+		require.Equal(t, "admin", config.GetUsername())
+		require.Equal(t, "admin", config.GetPassword())
+		require.Empty(t, config.GetType())
+	})
+
+	t.Run("Returns config for OpenSearch with bearer token", func(t *testing.T) {
+		config, err := ParseConfig(map[string]string{
+			ConfigKeyVersion:     elasticsearch.VersionOpenSearch1,
+			ConfigKeyHost:        "https://127.0.0.1:9200",
+			ConfigKeyBearerToken: "eyJhbGciOiJIUzI1NiJ9",
+			ConfigKeyIndex:       "users",
+			ConfigKeyBulkSize:    "1",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "eyJhbGciOiJIUzI1NiJ9", config.GetBearerToken())
+	})
+
+	t.Run("Returns config with lifecycle index template when ILM Policy was provided", func(t *testing.T) {
+		config, err := ParseConfig(map[string]string{
+			ConfigKeyVersion:           elasticsearch.Version7,
+			ConfigKeyHost:              fakerInstance.Internet().URL(),
+			ConfigKeyIndex:             "users",
+			ConfigKeyBulkSize:          "1",
+			ConfigKeyILMPolicy:         "users-policy",
+			ConfigKeyILMWarmMinAge:     "7d",
+			ConfigKeyILMDeleteMinAge:   "30d",
+			ConfigKeyTemplateVersion:   "2",
+			ConfigKeyIndexTemplateName: "users-template",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "users-policy", config.ILMPolicy)
+		require.Equal(t, "30d", config.ILMRolloverMaxAge)
+		require.Equal(t, "50gb", config.ILMRolloverMaxSize)
+		require.Equal(t, uint64(0), config.ILMRolloverMaxDocs)
+		require.Equal(t, "7d", config.ILMWarmMinAge)
+		require.Equal(t, "30d", config.ILMDeleteMinAge)
+		require.Equal(t, "users-template", config.IndexTemplateName)
+		require.Equal(t, uint64(0), config.MappingInferenceSamples)
+		require.JSONEq(t, `{
+			"index_patterns": ["users-*"],
+			"template": {
+				"settings": {
+					"index.lifecycle.name": "users-policy",
+					"index.lifecycle.rollover_alias": "users"
+				}
+			}
+		}`, string(config.IndexTemplate))
+	})
+
+	t.Run("Reads Index Settings from file", func(t *testing.T) {
+		settings := `{"settings":{"number_of_shards":1}}`
+
+		settingsFile := filepath.Join(t.TempDir(), "settings.json")
+		require.NoError(t, os.WriteFile(settingsFile, []byte(settings), 0o600))
+
+		config, err := ParseConfig(map[string]string{
+			ConfigKeyVersion:           elasticsearch.Version8,
+			ConfigKeyHost:              fakerInstance.Internet().URL(),
+			ConfigKeyIndex:             fakerInstance.Lorem().Word(),
+			ConfigKeyBulkSize:          "1",
+			ConfigKeyIndexSettingsFile: settingsFile,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, settings, string(config.IndexSettings))
+	})
+}
+
+func TestParseConfig_TLS(t *testing.T) {
+	fakerInstance := faker.New()
+
+	var (
+		dir                         = t.TempDir()
+		certFile, keyFile           = writeTestCertificate(t, dir, "client")
+		otherCertFile, otherKeyFile = writeTestCertificate(t, dir, "other")
+		invalidFile                 = filepath.Join(dir, "invalid.pem")
+	)
+
+	require.NoError(t, os.WriteFile(invalidFile, []byte("invalid"), 0o600))
+
+	newConfig := func(tlsCfg map[string]string) map[string]string {
+		cfgRaw := map[string]string{
+			ConfigKeyVersion:  elasticsearch.Version8,
+			ConfigKeyHost:     fakerInstance.Internet().URL(),
+			ConfigKeyIndex:    fakerInstance.Lorem().Word(),
+			ConfigKeyBulkSize: "1",
+		}
+
+		for key, value := range tlsCfg {
+			cfgRaw[key] = value
+		}
+
+		return cfgRaw
+	}
+
+	for _, tt := range []struct {
+		name  string
+		error string
+		cfg   map[string]string
+	}{
+		{
+			name:  "CA Cert File does not exist",
+			error: fmt.Sprintf("failed to read %q config value: open %s: no such file or directory", ConfigKeyTLSCACertFile, filepath.Join(dir, "missing.pem")),
+			cfg: map[string]string{
+				ConfigKeyTLSCACertFile: filepath.Join(dir, "missing.pem"),
+			},
+		},
+		{
+			name:  "CA Cert File contains no certificates",
+			error: fmt.Sprintf("failed to parse %q config value: no PEM encoded certificates found", ConfigKeyTLSCACertFile),
+			cfg: map[string]string{
+				ConfigKeyTLSCACertFile: invalidFile,
+			},
+		},
+		{
+			name:  "Client Cert File is provided but Client Key File is empty",
+			error: fmt.Sprintf("%q config value must be set when %q is provided", ConfigKeyTLSClientKeyFile, ConfigKeyTLSClientCertFile),
+			cfg: map[string]string{
+				ConfigKeyTLSClientCertFile: certFile,
+			},
+		},
+		{
+			name:  "Client Key File is provided but Client Cert File is empty",
+			error: fmt.Sprintf("%q config value must be set when %q is provided", ConfigKeyTLSClientCertFile, ConfigKeyTLSClientKeyFile),
+			cfg: map[string]string{
+				ConfigKeyTLSClientKeyFile: keyFile,
+			},
+		},
+		{
+			name:  "Client Key does not match Client Cert",
+			error: fmt.Sprintf("failed to parse %q and %q config values: tls: private key does not match public key", ConfigKeyTLSClientCertFile, ConfigKeyTLSClientKeyFile),
+			cfg: map[string]string{
+				ConfigKeyTLSClientCertFile: certFile,
+				ConfigKeyTLSClientKeyFile:  otherKeyFile,
+			},
+		},
+		{
+			name:  "Min Version is invalid",
+			error: fmt.Sprintf("%q config value must be one of [1.0, 1.1, 1.2, 1.3], 2.0 provided", ConfigKeyTLSMinVersion),
+			cfg: map[string]string{
+				ConfigKeyTLSMinVersion: "2.0",
+			},
+		},
+		{
+			name:  "Insecure Skip Verify is invalid",
+			error: fmt.Sprintf(`failed to parse %q config value: strconv.ParseBool: parsing "maybe": invalid syntax`, ConfigKeyTLSInsecureSkipVerify),
+			cfg: map[string]string{
+				ConfigKeyTLSInsecureSkipVerify: "maybe",
+			},
+		},
+		{
+			name:  "Certificate Fingerprint is provided together with TLS config values",
+			error: fmt.Sprintf("%q config value must not be set when TLS config values are provided", ConfigKeyCertificateFingerprint),
+			cfg: map[string]string{
+				ConfigKeyTLSCACertFile:          otherCertFile,
+				ConfigKeyCertificateFingerprint: fakerInstance.Hash().SHA256(),
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("Fails when: %s", tt.name), func(t *testing.T) {
+			_, err := ParseConfig(newConfig(tt.cfg))
+
+			require.EqualError(t, err, tt.error)
+		})
+	}
+
+	t.Run("Returns config without TLS config when no TLS config values were provided", func(t *testing.T) {
+		config, err := ParseConfig(newConfig(nil))
+
+		require.NoError(t, err)
+		require.Nil(t, config.TLS)
+	})
+
+	t.Run("Returns config with TLS config when all TLS config values were provided", func(t *testing.T) {
+		config, err := ParseConfig(newConfig(map[string]string{
+			ConfigKeyTLSCACertFile:         otherCertFile,
+			ConfigKeyTLSClientCertFile:     certFile,
+			ConfigKeyTLSClientKeyFile:      keyFile,
+			ConfigKeyTLSMinVersion:         "1.3",
+			ConfigKeyTLSInsecureSkipVerify: "true",
+		}))
+
+		require.NoError(t, err)
+		require.NotNil(t, config.TLS)
+		require.NotNil(t, config.TLS.RootCAs)
+		require.Len(t, config.TLS.Certificates, 1)
+		require.Equal(t, uint16(tls.VersionTLS13), config.TLS.MinVersion)
+		require.True(t, config.TLS.InsecureSkipVerify)
+		require.Same(t, config.TLS, config.GetTLSConfig())
+	})
+}
+
+func TestParseConfig_AWSSigV4(t *testing.T) {
+	fakerInstance := faker.New()
+
+	newConfig := func(awsCfg map[string]string) map[string]string {
+		cfgRaw := map[string]string{
+			ConfigKeyVersion:  elasticsearch.Version7,
+			ConfigKeyHost:     fakerInstance.Internet().URL(),
+			ConfigKeyIndex:    fakerInstance.Lorem().Word(),
+			ConfigKeyBulkSize: "1",
+			ConfigKeyAWSSigV4: "true",
+		}
+
+		for key, value := range awsCfg {
+			cfgRaw[key] = value
+		}
+
+		return cfgRaw
+	}
+
+	for _, tt := range []struct {
+		name  string
+		error string
+		cfg   map[string]string
+	}{
+		{
+			name:  "Username is provided",
+			error: fmt.Sprintf("%q config value must not be set when %q is provided", ConfigKeyUsername, ConfigKeyAWSSigV4),
+			cfg: map[string]string{
+				ConfigKeyUsername:  fakerInstance.Internet().Email(),
+				ConfigKeyAWSRegion: "eu-west-1",
+			},
+		},
+		{
+			name:  "Region is empty",
+			error: fmt.Sprintf("%q config value must be set when %q is provided", ConfigKeyAWSRegion, ConfigKeyAWSSigV4),
+			cfg:   map[string]string{},
+		},
+		{
+			name:  "Credentials source is invalid",
+			error: fmt.Sprintf("%q config value must be one of [static, environment, webIdentity], profile provided", ConfigKeyAWSCredentials),
+			cfg: map[string]string{
+				ConfigKeyAWSRegion:      "eu-west-1",
+				ConfigKeyAWSCredentials: "profile",
+			},
+		},
+		{
+			name:  "Secret Access Key is empty for static credentials",
+			error: fmt.Sprintf("%q config value must be set when %q is static", ConfigKeyAWSSecretAccessKey, ConfigKeyAWSCredentials),
+			cfg: map[string]string{
+				ConfigKeyAWSRegion:      "eu-west-1",
+				ConfigKeyAWSCredentials: "static",
+				ConfigKeyAWSAccessKeyID: "AKIDEXAMPLE",
+			},
+		},
+		{
+			name:  "Web Identity Token File does not exist",
+			error: fmt.Sprintf("failed to read %q config value: open /nonexistent/token: no such file or directory", ConfigKeyAWSWebIdentityTokenFile),
+			cfg: map[string]string{
+				ConfigKeyAWSRegion:               "eu-west-1",
+				ConfigKeyAWSCredentials:          "webIdentity",
+				ConfigKeyAWSRoleARN:              "arn:aws:iam::123456789012:role/conduit",
+				ConfigKeyAWSWebIdentityTokenFile: "/nonexistent/token",
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("Fails when: %s", tt.name), func(t *testing.T) {
+			_, err := ParseConfig(newConfig(tt.cfg))
+
+			require.EqualError(t, err, tt.error)
+		})
+	}
+
+	t.Run("Returns config with signer using static credentials", func(t *testing.T) {
+		config, err := ParseConfig(newConfig(map[string]string{
+			ConfigKeyAWSRegion:          "eu-west-1",
+			ConfigKeyAWSService:         "aoss",
+			ConfigKeyAWSCredentials:     "static",
+			ConfigKeyAWSAccessKeyID:     "AKIDEXAMPLE",
+			ConfigKeyAWSSecretAccessKey: "secret",
+			ConfigKeyAWSSessionToken:    "token",
+		}))
+
+		require.NoError(t, err)
+		require.Equal(t, &sigv4.Signer{
+			Region:  "eu-west-1",
+			Service: "aoss",
+			Credentials: sigv4.StaticCredentials{
+				AccessKeyID:     "AKIDEXAMPLE",
+				SecretAccessKey: "secret",
+				SessionToken:    "token",
+			},
+		}, config.GetSigner())
+	})
+
+	t.Run("Returns config with signer using environment credentials by default", func(t *testing.T) {
+		config, err := ParseConfig(newConfig(map[string]string{
+			ConfigKeyAWSRegion: "eu-west-1",
+		}))
+
+		require.NoError(t, err)
+		require.Equal(t, &sigv4.Signer{
+			Region:      "eu-west-1",
+			Service:     "es",
+			Credentials: sigv4.EnvironmentCredentials{},
+		}, config.GetSigner())
+	})
+
+	t.Run("Returns config with signer using web identity from environment", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte("token"), 0o600))
+
+		t.Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/conduit")
+		t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", tokenFile)
+
+		config, err := ParseConfig(newConfig(map[string]string{
+			ConfigKeyAWSRegion:      "eu-west-1",
+			ConfigKeyAWSCredentials: "webIdentity",
+		}))
+
+		require.NoError(t, err)
+		require.Equal(t, &sigv4.WebIdentityCredentials{
+			RoleARN:     "arn:aws:iam::123456789012:role/conduit",
+			TokenFile:   tokenFile,
+			SessionName: "conduit-connector-elasticsearch",
+			Endpoint:    "https://sts.eu-west-1.amazonaws.com/",
+		}, config.Signer.Credentials)
+	})
+
+	t.Run("Returns config without signer when signing is not enabled", func(t *testing.T) {
+		config, err := ParseConfig(newConfig(map[string]string{
+			ConfigKeyAWSSigV4: "false",
+		}))
+
+		require.NoError(t, err)
+		require.Nil(t, config.GetSigner())
+	})
+}
+
+func TestConfig_ResolveVersion(t *testing.T) {
+	fakerInstance := faker.New()
+
+	newConfig := func(t *testing.T, cfgRaw map[string]string) Config {
+		cfgRaw[ConfigKeyVersion] = elasticsearch.VersionAuto
+		cfgRaw[ConfigKeyHost] = fakerInstance.Internet().URL()
+		cfgRaw[ConfigKeyIndex] = "users"
+		cfgRaw[ConfigKeyBulkSize] = "1"
+
+		config, err := ParseConfig(cfgRaw)
+		require.NoError(t, err)
+
+		return config
+	}
+
+	t.Run("Fails when Type is empty for detected Version=6", func(t *testing.T) {
+		config := newConfig(t, map[string]string{})
+
+		require.EqualError(t, config.resolveVersion(elasticsearch.Version6), fmt.Sprintf("%q config value must be set", ConfigKeyType))
+	})
+
+	t.Run("Fails when API Key is provided for detected Version=opensearch1", func(t *testing.T) {
+		config := newConfig(t, map[string]string{
+			ConfigKeyAPIKey: fakerInstance.Internet().Password(),
+		})
+
+		require.EqualError(t, config.resolveVersion(elasticsearch.VersionOpenSearch1), fmt.Sprintf("%q config value is not supported by OpenSearch", ConfigKeyAPIKey))
+	})
+
+	t.Run("Fails when Sniff On Start is enabled for detected Version=5", func(t *testing.T) {
+		config := newConfig(t, map[string]string{
+			ConfigKeyType:         "user",
+			ConfigKeySniffOnStart: "true",
+		})
+
+		require.EqualError(t, config.resolveVersion(elasticsearch.Version5), fmt.Sprintf("%q config value is supported only for versions 6 and newer", ConfigKeySniffOnStart))
+	})
+
+	t.Run("Prepares lifecycle index template of detected version", func(t *testing.T) {
+		config := newConfig(t, map[string]string{
+			ConfigKeyType:      "user",
+			ConfigKeyILMPolicy: "users-policy",
+		})
+
+		require.Nil(t, config.IndexTemplate)
+		require.NoError(t, config.resolveVersion(elasticsearch.Version6))
+		require.Equal(t, elasticsearch.Version6, config.Version)
+		require.JSONEq(t, `{
+			"index_patterns": ["users-*"],
+			"settings": {
+				"index.lifecycle.name": "users-policy",
+				"index.lifecycle.rollover_alias": "users"
+			}
+		}`, string(config.IndexTemplate))
 	})
 }
 
@@ -269,3 +1320,32 @@ func TestConfig_Getters(t *testing.T) {
 	require.Equal(t, indexName, config.GetIndex())
 	require.Equal(t, indexType, config.GetType())
 }
+
+// writeTestCertificate writes self-signed certificate and its private key as PEM files into the dir.
+func writeTestCertificate(t *testing.T, dir, name string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
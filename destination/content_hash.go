@@ -0,0 +1,77 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// contentHashID returns the Document ID derived from the SHA-256 hash of the canonicalized payload.
+// When fields are provided, only their values are hashed, in the given order.
+// Raw payloads which are not a JSON object are hashed as they are.
+func contentHashID(payload sdk.Data, fields []string) (string, error) {
+	var payloadFields map[string]interface{}
+
+	switch typedPayload := payload.(type) {
+	case sdk.StructuredData:
+		payloadFields = typedPayload
+
+	case sdk.RawData:
+		decoder := json.NewDecoder(bytes.NewReader(typedPayload))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&payloadFields); err != nil || payloadFields == nil {
+			return hashBytes(typedPayload), nil
+		}
+
+	default:
+		if payload == nil {
+			return hashBytes(nil), nil
+		}
+
+		return hashBytes(payload.Bytes()), nil
+	}
+
+	// JSON encoding sorts object keys, which makes the encoded payload canonical
+	var hashed interface{} = payloadFields
+
+	if len(fields) > 0 {
+		values := make([]interface{}, len(fields))
+
+		for n, path := range fields {
+			values[n], _ = getFieldPath(payloadFields, path)
+		}
+
+		hashed = values
+	}
+
+	canonical, err := json.Marshal(hashed)
+	if err != nil {
+		return "", err
+	}
+
+	return hashBytes(canonical), nil
+}
+
+func hashBytes(data []byte) string {
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,120 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentHashID(t *testing.T) {
+	t.Run("Returns the same ID for equal payloads", func(t *testing.T) {
+		structuredID, err := contentHashID(sdk.StructuredData{
+			"name": "John",
+			"id":   1,
+			"address": map[string]interface{}{
+				"zip":  "00-001",
+				"city": "Warsaw",
+			},
+		}, nil)
+
+		require.NoError(t, err)
+		require.Len(t, structuredID, 64)
+
+		rawID, err := contentHashID(sdk.RawData(`{"address":{"city":"Warsaw","zip":"00-001"},"id":1,"name":"John"}`), nil)
+
+		require.NoError(t, err)
+		require.Equal(t, structuredID, rawID)
+	})
+
+	t.Run("Returns different IDs for different payloads", func(t *testing.T) {
+		id1, err := contentHashID(sdk.StructuredData{"id": 1, "name": "John"}, nil)
+
+		require.NoError(t, err)
+
+		id2, err := contentHashID(sdk.StructuredData{"id": 1, "name": "Jane"}, nil)
+
+		require.NoError(t, err)
+		require.NotEqual(t, id1, id2)
+	})
+
+	t.Run("Hashes selected fields only", func(t *testing.T) {
+		fields := []string{"id", "address.city"}
+
+		id1, err := contentHashID(sdk.StructuredData{
+			"id":         1,
+			"address":    map[string]interface{}{"city": "Warsaw"},
+			"ingestedAt": "2022-10-05T12:00:00Z",
+		}, fields)
+
+		require.NoError(t, err)
+
+		id2, err := contentHashID(sdk.RawData(`{"id":1,"address":{"city":"Warsaw"},"ingestedAt":"2022-10-05T12:30:00Z"}`), fields)
+
+		require.NoError(t, err)
+		require.Equal(t, id1, id2)
+
+		id3, err := contentHashID(sdk.StructuredData{"id": 1, "address": map[string]interface{}{"city": "Cracow"}}, fields)
+
+		require.NoError(t, err)
+		require.NotEqual(t, id1, id3)
+	})
+
+	t.Run("Hashes raw payload which is not JSON object as it is", func(t *testing.T) {
+		id, err := contentHashID(sdk.RawData("foo"), nil)
+
+		require.NoError(t, err)
+		require.Equal(t, "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", id)
+	})
+
+	t.Run("Fails when payload could not be encoded", func(t *testing.T) {
+		_, err := contentHashID(sdk.StructuredData{"foo": complex64(1 + 2i)}, nil)
+
+		require.EqualError(t, err, "json: unsupported type: complex64")
+	})
+}
+
+func TestDestination_WriteAsync_ContentHashID(t *testing.T) {
+	t.Run("Indexes Record without Key with content hash ID", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareIndexOperationFunc: func(key string, item sdk.Record, version uint64) (interface{}, interface{}, error) {
+				return key, item.Payload, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:      10,
+				ContentHashID: true,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		record := sdk.Record{
+			Payload: sdk.RawData("foo"),
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), record, successfulAckFunc(t)))
+		require.NoError(t, destination.WriteAsync(context.Background(), record, successfulAckFunc(t)))
+		require.Len(t, esClientMock.PrepareIndexOperationCalls(), 2)
+		require.Equal(t, "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", esClientMock.PrepareIndexOperationCalls()[0].Key)
+		require.Equal(t, esClientMock.PrepareIndexOperationCalls()[0].Key, esClientMock.PrepareIndexOperationCalls()[1].Key)
+		require.Len(t, esClientMock.PrepareCreateOperationCalls(), 0)
+	})
+}
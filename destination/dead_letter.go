@@ -0,0 +1,63 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"time"
+)
+
+// deadLetterDocument describes a Document which could not be stored in the destination index.
+// The original payload and error details are kept as strings, so the dead letter index mapping
+// does not conflict with the Documents which failed because of their mapping.
+type deadLetterDocument struct {
+	Index     string            `json:"index"`
+	Action    string            `json:"action,omitempty"`
+	Key       string            `json:"key,omitempty"`
+	Payload   string            `json:"payload"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Status    int               `json:"status,omitempty"`
+	ErrorType string            `json:"error_type,omitempty"`
+	Reason    string            `json:"reason,omitempty"`
+	CausedBy  string            `json:"caused_by,omitempty"`
+	Attempts  int               `json:"attempts"`
+	FailedAt  time.Time         `json:"failed_at"`
+}
+
+func newDeadLetterDocument(index string, item *operation) deadLetterDocument {
+	document := deadLetterDocument{
+		Index:    index,
+		Action:   item.resultType,
+		Key:      recordKey(item.Record),
+		Metadata: item.Record.Metadata,
+		Status:   item.result.Status,
+		Attempts: item.attempts,
+		FailedAt: time.Now().UTC(),
+	}
+
+	if item.Record.Payload != nil {
+		document.Payload = string(item.Record.Payload.Bytes())
+	}
+
+	if item.result.Error != nil {
+		document.ErrorType = item.result.Error.Type
+		document.Reason = item.result.Error.Reason
+
+		if causedBy := item.result.Error.CausedBy; len(causedBy) > 0 && string(causedBy) != "null" {
+			document.CausedBy = string(causedBy)
+		}
+	}
+
+	return document
+}
@@ -0,0 +1,159 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// Debezium change event operations.
+// See: https://debezium.io/documentation/reference/stable/connectors/postgresql.html#postgresql-events
+const (
+	debeziumOperationCreate = "c"
+	debeziumOperationUpdate = "u"
+	debeziumOperationDelete = "d"
+	debeziumOperationRead   = "r"
+)
+
+// debeziumEnvelope is the change event of a single row.
+type debeziumEnvelope struct {
+	Op    string
+	After map[string]interface{}
+	TsMs  uint64
+}
+
+// parseDebeziumEnvelope returns the change event when the payload is a Debezium envelope.
+// Envelopes wrapped with their schema, as produced by the JSON converter with schemas enabled, are unwrapped.
+func parseDebeziumEnvelope(payload sdk.Data) (debeziumEnvelope, bool) {
+	var fields map[string]interface{}
+
+	switch typedPayload := payload.(type) {
+	case sdk.StructuredData:
+		fields = typedPayload
+
+	case sdk.RawData:
+		decoder := json.NewDecoder(bytes.NewReader(typedPayload))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&fields); err != nil {
+			return debeziumEnvelope{}, false
+		}
+
+	default:
+		return debeziumEnvelope{}, false
+	}
+
+	if _, hasSchema := fields["schema"]; hasSchema {
+		if wrappedFields, ok := asFields(fields["payload"]); ok {
+			fields = wrappedFields
+		}
+	}
+
+	op, _ := fields["op"].(string)
+	_, hasBefore := fields["before"]
+	_, hasAfter := fields["after"]
+
+	if op == "" || (!hasBefore && !hasAfter) {
+		return debeziumEnvelope{}, false
+	}
+
+	envelope := debeziumEnvelope{
+		Op: op,
+	}
+
+	if after, ok := asFields(fields["after"]); ok {
+		envelope.After = after
+	}
+
+	switch tsMs := fields["ts_ms"].(type) {
+	case json.Number:
+		if value, err := tsMs.Int64(); err == nil && value > 0 {
+			envelope.TsMs = uint64(value)
+		}
+
+	case float64:
+		if tsMs > 0 {
+			envelope.TsMs = uint64(tsMs)
+		}
+
+	case int64:
+		if tsMs > 0 {
+			envelope.TsMs = uint64(tsMs)
+		}
+
+	case int:
+		if tsMs > 0 {
+			envelope.TsMs = uint64(tsMs)
+		}
+	}
+
+	return envelope, true
+}
+
+// writeDebeziumOperation adds the operation described by the change event into Bulk API request.
+// Created, updated and read rows replace the whole Document, deleted rows delete it.
+// Returns errOperationSkipped for change events which do not describe any operation.
+func (d *Destination) writeDebeziumOperation(
+	ctx context.Context,
+	data *bytes.Buffer,
+	record sdk.Record,
+	envelope debeziumEnvelope,
+) error {
+	key := recordKey(record)
+
+	var version uint64
+	if d.config.DebeziumVersioning {
+		version = envelope.TsMs
+	}
+
+	switch envelope.Op {
+	case debeziumOperationCreate, debeziumOperationUpdate, debeziumOperationRead:
+		if envelope.After == nil {
+			sdk.Logger(ctx).Warn().Str("key", key).Msgf("change event of %q operation has no state after the change", envelope.Op)
+
+			return errOperationSkipped
+		}
+
+		record.Payload = sdk.StructuredData(envelope.After)
+
+		if !d.config.FieldTransformations.Empty() {
+			record.Payload = d.config.FieldTransformations.Apply(record.Payload)
+		}
+
+		if key == "" {
+			return d.writeKeylessOperation(data, record)
+		}
+
+		return d.writeIndexOperation(key, version, data, record)
+
+	case debeziumOperationDelete:
+		if key == "" {
+			sdk.Logger(ctx).Warn().Msg("change event of delete operation has no key")
+
+			return errOperationSkipped
+		}
+
+		return d.writeDeleteOperation(key, version, data)
+
+	default:
+		sdk.Logger(ctx).Warn().Str("key", key).Msgf("unsupported change event operation: %s", envelope.Op)
+
+		return errOperationSkipped
+	}
+}
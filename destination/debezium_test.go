@@ -0,0 +1,222 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDebeziumEnvelope(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		payload  sdk.Data
+		envelope debeziumEnvelope
+		ok       bool
+	}{
+		{
+			name: "structured envelope",
+			payload: sdk.StructuredData{
+				"before": nil,
+				"after":  map[string]interface{}{"id": 1, "name": "John"},
+				"op":     "c",
+				"ts_ms":  int64(1665000000000),
+			},
+			envelope: debeziumEnvelope{
+				Op:    "c",
+				After: map[string]interface{}{"id": 1, "name": "John"},
+				TsMs:  1665000000000,
+			},
+			ok: true,
+		},
+		{
+			name:    "raw envelope wrapped with schema",
+			payload: sdk.RawData(`{"schema":{"type":"struct"},"payload":{"before":{"id":1},"after":null,"op":"d","ts_ms":1665000000000}}`),
+			envelope: debeziumEnvelope{
+				Op:   "d",
+				TsMs: 1665000000000,
+			},
+			ok: true,
+		},
+		{
+			name:    "raw envelope without timestamp",
+			payload: sdk.RawData(`{"before":null,"after":{"id":1},"op":"r"}`),
+			envelope: debeziumEnvelope{
+				Op:    "r",
+				After: map[string]interface{}{"id": json.Number("1")},
+			},
+			ok: true,
+		},
+		{
+			name:    "payload without operation",
+			payload: sdk.StructuredData{"after": map[string]interface{}{"id": 1}},
+		},
+		{
+			name:    "payload without state",
+			payload: sdk.StructuredData{"op": "c", "id": 1},
+		},
+		{
+			name:    "raw payload which is not JSON",
+			payload: sdk.RawData("op"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, ok := parseDebeziumEnvelope(tt.payload)
+
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.envelope, envelope)
+		})
+	}
+}
+
+func TestDestination_WriteAsync_Debezium(t *testing.T) {
+	newDestination := func(esClientMock *clientMock, versioning bool) Destination {
+		return Destination{
+			config: Config{
+				BulkSize:           10,
+				DebeziumEnvelope:   true,
+				DebeziumVersioning: versioning,
+			},
+			client:          esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+	}
+
+	t.Run("Indexes state after the change", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareIndexOperationFunc: func(key string, item sdk.Record, version uint64) (interface{}, interface{}, error) {
+				return key, item.Payload, nil
+			},
+		}
+
+		destination := newDestination(&esClientMock, true)
+
+		record := sdk.Record{
+			Metadata: map[string]string{"action": "insert"},
+			Key:      sdk.RawData("1"),
+			Payload:  sdk.RawData(`{"before":{"id":1,"name":"Jane"},"after":{"id":1,"name":"John"},"op":"u","ts_ms":1665000000000}`),
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), record, successfulAckFunc(t)))
+		require.Len(t, esClientMock.PrepareIndexOperationCalls(), 1)
+		require.Equal(t, "1", esClientMock.PrepareIndexOperationCalls()[0].Key)
+		require.Equal(t, uint64(1665000000000), esClientMock.PrepareIndexOperationCalls()[0].Version)
+		require.Equal(
+			t,
+			sdk.StructuredData{"id": json.Number("1"), "name": "John"},
+			esClientMock.PrepareIndexOperationCalls()[0].Item.Payload,
+		)
+	})
+
+	t.Run("Deletes Document of deleted row", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareDeleteOperationFunc: func(key string, version uint64) (interface{}, error) {
+				return key, nil
+			},
+		}
+
+		destination := newDestination(&esClientMock, false)
+
+		record := sdk.Record{
+			Key:     sdk.RawData("1"),
+			Payload: sdk.StructuredData{"before": map[string]interface{}{"id": 1}, "after": nil, "op": "d", "ts_ms": 1665000000000},
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), record, successfulAckFunc(t)))
+		require.Len(t, esClientMock.PrepareDeleteOperationCalls(), 1)
+		require.Equal(t, "1", esClientMock.PrepareDeleteOperationCalls()[0].Key)
+		require.Equal(t, uint64(0), esClientMock.PrepareDeleteOperationCalls()[0].Version)
+	})
+
+	t.Run("Writes payloads which are not change events as usual", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item sdk.Record) (interface{}, interface{}, error) {
+				return key, item.Payload, nil
+			},
+		}
+
+		destination := newDestination(&esClientMock, false)
+
+		record := sdk.Record{
+			Key:     sdk.RawData("1"),
+			Payload: sdk.StructuredData{"id": 1, "op": "c"},
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), record, successfulAckFunc(t)))
+		require.Len(t, esClientMock.PrepareUpsertOperationCalls(), 1)
+		require.Len(t, esClientMock.PrepareIndexOperationCalls(), 0)
+	})
+	t.Run("Acks skipped change events without sending them", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareIndexOperationFunc: func(key string, item sdk.Record, version uint64) (interface{}, interface{}, error) {
+				return key, item.Payload, nil
+			},
+
+			PrepareDeleteOperationFunc: func(key string, version uint64) (interface{}, error) {
+				return key, nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				body, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				require.Equal(t, "\"1\"\n{\"id\":1}\n\"4\"\n", string(body))
+
+				return io.NopCloser(strings.NewReader(`{"items":[{"index":{"_id":"1","status":200}},{"delete":{"_id":"4","status":404}}]}`)), nil
+			},
+		}
+
+		destination := newDestination(&esClientMock, false)
+
+		var acked []string
+
+		for _, record := range []sdk.Record{
+			{Key: sdk.RawData("1"), Payload: sdk.RawData(`{"before":null,"after":{"id":1},"op":"c"}`)},
+			{Key: sdk.RawData("2"), Payload: sdk.RawData(`{"before":{"id":2},"after":null,"op":"u"}`)},
+			{Payload: sdk.RawData(`{"before":{"id":3},"after":null,"op":"d"}`)},
+			{Key: sdk.RawData("4"), Payload: sdk.RawData(`{"before":{"id":4},"after":null,"op":"d"}`)},
+			{Key: sdk.RawData("5"), Payload: sdk.RawData(`{"before":null,"after":{"id":5},"op":"t"}`)},
+		} {
+			position := recordKey(record)
+			if position == "" {
+				position = "keyless"
+			}
+
+			require.NoError(t, destination.WriteAsync(context.Background(), record, func(err error) error {
+				acked = append(acked, position)
+
+				if position == "4" {
+					require.EqualError(t, err, "item with key=4 delete failure: unknown error")
+				} else {
+					require.NoError(t, err)
+				}
+
+				return nil
+			}))
+		}
+
+		require.Equal(t, []string{"2", "keyless", "5"}, acked)
+		require.Equal(t, 2, destination.operationsQueue.Len())
+
+		require.NoError(t, destination.Flush(context.Background()))
+		require.Len(t, esClientMock.BulkCalls(), 1)
+		require.Equal(t, []string{"2", "keyless", "5", "1", "4"}, acked)
+	})
+}
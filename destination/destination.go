@@ -18,13 +18,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"sync"
+	"time"
 
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/miquido/conduit-connector-elasticsearch/internal"
 	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func NewDestination() sdk.Destination {
@@ -34,10 +40,20 @@ func NewDestination() sdk.Destination {
 type Destination struct {
 	sdk.UnimplementedDestination
 
-	config          Config
-	client          client
-	mutex           sync.Mutex
-	operationsQueue BufferQueue
+	config              Config
+	serverInfo          elasticsearch.ServerInfo
+	client              client
+	mutex               sync.Mutex
+	operationsQueue     BufferQueue
+	operationsQueueSize uint64
+	workers             *bulkWorkers
+	pendingAcks         pendingAcks
+	metrics             *metrics
+	metricsServer       *http.Server
+	tracerProvider      trace.TracerProvider
+	deadLetterClient    client
+	mappingSamples      []sdk.StructuredData
+	mappingInferred     bool
 }
 
 //go:generate moq -out client_moq_test.go . client
@@ -48,6 +64,15 @@ func (d *Destination) GetClient() elasticsearch.Client {
 	return d.client
 }
 
+// Metrics returns the registry of the destination metrics, available once the destination is opened.
+func (d *Destination) Metrics() prometheus.Gatherer {
+	if d.metrics == nil {
+		return nil
+	}
+
+	return d.metrics.registry
+}
+
 func (d *Destination) Configure(_ context.Context, cfgRaw map[string]string) (err error) {
 	d.config, err = ParseConfig(cfgRaw)
 
@@ -55,6 +80,11 @@ func (d *Destination) Configure(_ context.Context, cfgRaw map[string]string) (er
 }
 
 func (d *Destination) Open(ctx context.Context) (err error) {
+	// Detect or verify the version of the server
+	if err := d.resolveVersion(ctx); err != nil {
+		return fmt.Errorf("connection could not be established: %w", err)
+	}
+
 	// Initialize Elasticsearch client
 	d.client, err = elasticsearch.NewClient(d.config.Version, d.config)
 	if err != nil {
@@ -66,9 +96,61 @@ func (d *Destination) Open(ctx context.Context) (err error) {
 		return fmt.Errorf("connection could not be established: %w", err)
 	}
 
+	// Install index and component templates
+	if len(d.config.IndexTemplate) > 0 || len(d.config.ComponentTemplates) > 0 {
+		if err := d.ensureTemplates(ctx); err != nil {
+			return fmt.Errorf("templates could not be prepared: %w", err)
+		}
+	}
+
+	// Create or update the index lifecycle policy
+	if d.config.ILMPolicy != "" {
+		if err := d.ensureLifecyclePolicy(ctx); err != nil {
+			return fmt.Errorf("index lifecycle could not be prepared: %w", err)
+		}
+	}
+
+	// Create the index or check the mapping of the existing one
+	if len(d.config.IndexSettings) > 0 || d.config.ILMPolicy != "" {
+		if err := d.ensureIndex(ctx); err != nil {
+			return fmt.Errorf("index could not be prepared: %w", err)
+		}
+	}
+
+	// Initialize dead letter index client, sharing all settings except for the index name
+	if d.config.DeadLetterIndex != "" {
+		deadLetterConfig := d.config
+		deadLetterConfig.Index = d.config.DeadLetterIndex
+
+		d.deadLetterClient, err = elasticsearch.NewClient(d.config.Version, deadLetterConfig)
+		if err != nil {
+			return fmt.Errorf("dead letter index client could not be created: %w", err)
+		}
+	}
+
 	// Initialize the buffer
 	d.mutex = sync.Mutex{}
 	d.operationsQueue = make(BufferQueue, 0, d.config.BulkSize)
+	d.operationsQueueSize = 0
+	d.pendingAcks = pendingAcks{}
+
+	// Initialize metrics, served by the local listener when its address is set
+	d.metrics = newMetrics()
+
+	if d.config.MetricsAddress != "" {
+		if d.metricsServer, err = serveMetrics(ctx, d.config.MetricsAddress, d.metrics.registry); err != nil {
+			return fmt.Errorf("metrics listener could not be started: %w", err)
+		}
+	}
+
+	// Reset mapping inference
+	d.mappingSamples = nil
+	d.mappingInferred = false
+
+	// Start bulk workers, a single worker sends requests synchronously
+	if d.config.BulkWorkers > 1 {
+		d.workers = newBulkWorkers(ctx, int(d.config.BulkWorkers), d.executeOperations)
+	}
 
 	return nil
 }
@@ -77,14 +159,56 @@ func (d *Destination) WriteAsync(ctx context.Context, record sdk.Record, ackFunc
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	// Encode the operation up front, so its size is known before it is queued
+	data := &bytes.Buffer{}
+
+	if err := d.writeOperation(ctx, data, record); err != nil {
+		// Records which do not translate into any operation are acked right away, as nothing is sent for them
+		if errors.Is(err, errOperationSkipped) {
+			return ackFunc(nil)
+		}
+
+		return err
+	}
+
+	payloadSize := uint64(data.Len())
+
+	if d.config.MappingInferenceSamples > 0 {
+		d.sampleMappingRecord(record)
+	}
+
+	if d.config.BulkMaxBytes > 0 {
+		// Records which would never fit into a bulk request are failed on their own
+		if payloadSize > d.config.BulkMaxBytes {
+			return ackFunc(fmt.Errorf(
+				"item with key=%s failure: encoded size of %d bytes exceeds %q limit of %d bytes",
+				recordKey(record),
+				payloadSize,
+				ConfigKeyBulkMaxBytes,
+				d.config.BulkMaxBytes,
+			))
+		}
+
+		// Send pending operations first when the new one would not fit
+		if d.operationsQueueSize+payloadSize > d.config.BulkMaxBytes {
+			if err := d.flushOperationsQueue(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
 	d.operationsQueue.Enqueue(&operation{
 		CreatedAt: record.CreatedAt,
 		Record:    record,
-		AckFunc:   ackFunc,
+		AckFunc:   d.pendingAcks.Track(ackFunc),
+		payload:   data.Bytes(),
 	})
+	d.operationsQueueSize += payloadSize
+	d.metrics.setBufferDepth(d.operationsQueue.Len())
 
-	if uint64(d.operationsQueue.Len()) >= d.config.BulkSize {
-		if err := d.Flush(ctx); err != nil {
+	if uint64(d.operationsQueue.Len()) >= d.config.BulkSize ||
+		(d.config.BulkMaxBytes > 0 && d.operationsQueueSize >= d.config.BulkMaxBytes) {
+		if err := d.flushOperationsQueue(ctx); err != nil {
 			return err
 		}
 	}
@@ -92,170 +216,453 @@ func (d *Destination) WriteAsync(ctx context.Context, record sdk.Record, ackFunc
 	return nil
 }
 
-func (d *Destination) Flush(ctx context.Context) error {
+func (d *Destination) Flush(ctx context.Context) (err error) {
+	ctx, span := d.startSpan(ctx, "Flush", attribute.Int("records", d.operationsQueue.Len()))
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	if err := d.flushOperationsQueue(ctx); err != nil {
+		return err
+	}
+
+	// Wait for operations handed over to bulk workers
+	if d.workers != nil {
+		return d.workers.Wait()
+	}
+
+	return nil
+}
+
+// flushOperationsQueue sends all pending operations and resets the buffer.
+// When bulk workers are enabled, operations are only handed over to workers and not awaited.
+func (d *Destination) flushOperationsQueue(ctx context.Context) (err error) {
 	// Check if there are operations in the buffer
 	if d.operationsQueue.Empty() {
 		return nil
 	}
 
+	// Started here, so flushes triggered by the buffer thresholds are traced as well
+	ctx, span := d.startSpan(ctx, "flushOperationsQueue", attribute.Int("records", d.operationsQueue.Len()))
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	// The index must exist before the first Document is written
+	if d.config.MappingInferenceSamples > 0 && !d.mappingInferred {
+		if err := d.createInferredIndex(ctx); err != nil {
+			return err
+		}
+	}
+
+	operations := d.operationsQueue
+
+	// Reset buffer
+	d.operationsQueue = make(BufferQueue, 0, d.config.BulkSize)
+	d.operationsQueueSize = 0
+	d.metrics.setBufferDepth(0)
+
+	if d.workers != nil {
+		return d.workers.Dispatch(ctx, operations)
+	}
+
+	return d.executeOperations(ctx, operations)
+}
+
+// executeOperations sends operations in Bulk API requests and acks them, retrying failed ones.
+func (d *Destination) executeOperations(ctx context.Context, operations BufferQueue) error {
 	// Execute operations
 	retriesLeft := d.config.Retries
+	attempt := 0
 
-	for {
-		// Set up the buffer for failed operations
-		failedOperations := make(BufferQueue, 0, d.operationsQueue.Len())
+	// Set up the buffer for operations which will not be retried
+	permanentlyFailedOperations := make(BufferQueue, 0)
 
-		// Prepare request payload
-		data, err := d.prepareBulkRequestPayload(ctx)
+	for {
+		// Send operations and ack the results
+		failedOperations, permanentlyFailedRound, err := d.executeRound(ctx, attempt, operations)
 		if err != nil {
 			return err
 		}
 
-		// Send the bulk request
-		response, err := d.executeBulkRequest(ctx, data)
-		if err != nil {
+		for _, item := range permanentlyFailedRound {
+			permanentlyFailedOperations.Enqueue(item)
+		}
+
+		// Fail pending operations when retries limit is reached
+		if retriesLeft == 0 {
+			for _, failedOperation := range failedOperations {
+				permanentlyFailedOperations.Enqueue(failedOperation)
+			}
+
+			break
+		}
+
+		// Check if there are operations to retry
+		if failedOperations.Len() == 0 {
+			break
+		}
+
+		// Set up for retry
+		retriesLeft--
+		attempt++
+
+		d.metrics.observeRetries("item", failedOperations.Len())
+
+		operations = failedOperations
+
+		if err := d.config.retryBackoff().Wait(ctx, attempt); err != nil {
 			return err
 		}
+	}
+
+	return d.failOperations(ctx, permanentlyFailedOperations)
+}
+
+// executeRound sends operations in a single Bulk API request and acks them according to the results.
+// Returns operations to retry and operations which failed permanently.
+func (d *Destination) executeRound(
+	ctx context.Context,
+	attempt int,
+	operations BufferQueue,
+) (retryOperations, failedOperations BufferQueue, err error) {
+	ctx, span := d.startSpan(ctx, "executeRound",
+		attribute.Int("attempt", attempt),
+		attribute.Int("records", operations.Len()),
+	)
+	defer func() {
+		span.SetAttributes(
+			attribute.Int("retries", retryOperations.Len()),
+			attribute.Int("failures", failedOperations.Len()),
+		)
+		endSpan(span, err)
+	}()
+
+	// Set up the buffers for failed operations
+	retryOperations = make(BufferQueue, 0, operations.Len())
+	failedOperations = make(BufferQueue, 0)
+
+	// Prepare request payload
+	data, err := d.prepareBulkRequestPayload(ctx, operations)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		// Ack results
-		for n, item := range response.Items {
-			// Detect operation result
-			var itemResponse bulkResponseItem
-			var operationType string
+	// Send the bulk request
+	response, err := d.executeBulkRequest(ctx, d.client, data)
+	if err != nil {
+		return nil, nil, err
+	}
 
-			switch {
-			case item.Index != nil:
-				itemResponse = *item.Index
-				operationType = "index"
+	// Ack results
+	for n, item := range response.Items {
+		// Detect operation result
+		itemResponse, operationType, ok := item.Result()
+		if !ok {
+			sdk.Logger(ctx).Warn().Msg("no index, create, update or delete details were found in Elasticsearch response")
 
-			case item.Create != nil:
-				itemResponse = *item.Create
-				operationType = "create"
+			continue
+		}
 
-			case item.Update != nil:
-				itemResponse = *item.Update
-				operationType = "update"
+		// ACK
+		// The order of responses is the same as the order of requests
+		// https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html#bulk-api-response-body
+		ackFunc := operations[n].AckFunc
 
-			case item.Delete != nil:
-				itemResponse = *item.Delete
-				operationType = "delete"
+		operations[n].attempts++
+		operations[n].result = itemResponse
+		operations[n].resultType = operationType
 
-			default:
-				sdk.Logger(ctx).Warn().Msg("no index, create, update or delete details were found in Elasticsearch response")
+		d.metrics.observeItem(operationType, itemResponse)
 
-				continue
+		if itemResponse.Status >= 200 && itemResponse.Status < 300 {
+			if err := ackFunc(nil); err != nil {
+				return nil, nil, err
 			}
 
-			// ACK
-			// The order of responses is the same as the order of requests
-			// https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html#bulk-api-response-body
-			ackFunc := d.operationsQueue[n].AckFunc
+			continue
+		}
+
+		if itemResponse.Error == nil {
+			operations[n].err = fmt.Errorf(
+				"item with key=%s %s failure: unknown error",
+				itemResponse.ID,
+				operationType,
+			)
+		} else {
+			operations[n].err = fmt.Errorf(
+				"item with key=%s %s failure: [%s] %s: %s",
+				itemResponse.ID,
+				operationType,
+				itemResponse.Error.Type,
+				itemResponse.Error.Reason,
+				itemResponse.Error.CausedBy,
+			)
+		}
 
-			if itemResponse.Status >= 200 && itemResponse.Status < 300 {
-				if err := ackFunc(nil); err != nil {
-					return err
-				}
+		// Handle the failure according to its policy
+		switch policy := d.config.FailurePolicies.Resolve(operationType, itemResponse); policy {
+		case failurePolicySuccess:
+			if err := ackFunc(nil); err != nil {
+				return nil, nil, err
+			}
 
-				continue
+		case failurePolicySkip:
+			sdk.Logger(ctx).Warn().Err(operations[n].err).Msg("skipping failed item")
+
+			if err := ackFunc(nil); err != nil {
+				return nil, nil, err
 			}
 
-			if itemResponse.Error == nil {
-				d.operationsQueue[n].err = fmt.Errorf(
-					"item with key=%s %s failure: unknown error",
-					itemResponse.ID,
-					operationType,
-				)
-			} else {
-				d.operationsQueue[n].err = fmt.Errorf(
-					"item with key=%s %s failure: [%s] %s: %s",
-					itemResponse.ID,
-					operationType,
-					itemResponse.Error.Type,
-					itemResponse.Error.Reason,
-					itemResponse.Error.CausedBy,
-				)
+		case failurePolicyRetry:
+			retryOperations.Enqueue(operations[n])
+
+		case failurePolicyFail:
+			if err := ackFunc(operations[n].err); err != nil {
+				return nil, nil, err
 			}
 
-			failedOperations.Enqueue(d.operationsQueue[n])
+		default:
+			failedOperations.Enqueue(operations[n])
 		}
+	}
 
-		// Fail pending operations when retries limit is reached
-		if retriesLeft == 0 {
-			for _, failedOperation := range failedOperations {
-				if err := failedOperation.AckFunc(failedOperation.err); err != nil {
-					return err
-				}
+	return retryOperations, failedOperations, nil
+}
+
+// failOperations acks operations which failed permanently.
+// When the dead-letter index is set, failed Documents are stored there and their Records are acked as handled.
+func (d *Destination) failOperations(ctx context.Context, operations BufferQueue) error {
+	if operations.Empty() {
+		return nil
+	}
+
+	if d.deadLetterClient == nil {
+		for _, item := range operations {
+			if err := item.AckFunc(item.err); err != nil {
+				return err
 			}
+		}
 
-			break
+		return nil
+	}
+
+	// Prepare request payload
+	data := &bytes.Buffer{}
+
+	for _, item := range operations {
+		document, err := json.Marshal(newDeadLetterDocument(d.config.Index, item))
+		if err != nil {
+			return fmt.Errorf("failed to prepare dead letter: %w", err)
 		}
 
-		// Check if there are operations to retry
-		if failedOperations.Len() == 0 {
-			break
+		if err := d.writeDeadLetterOperation(data, document); err != nil {
+			return err
 		}
+	}
 
-		// Set up for retry
-		retriesLeft--
+	// Send the bulk request
+	response, err := d.executeBulkRequest(ctx, d.deadLetterClient, data)
+	if err != nil {
+		for _, item := range operations {
+			if err := item.AckFunc(fmt.Errorf("%s; dead letter failure: %w", item.err, err)); err != nil {
+				return err
+			}
+		}
 
-		d.operationsQueue = failedOperations
+		return nil
 	}
 
-	// Reset buffer
-	d.operationsQueue = make(BufferQueue, 0, d.config.BulkSize)
+	// Ack results
+	for n, item := range operations {
+		var itemResponse bulkResponseItem
+		if n < len(response.Items) {
+			itemResponse, _, _ = response.Items[n].Result()
+		}
+
+		if itemResponse.Status < 200 || itemResponse.Status >= 300 {
+			reason := "unknown error"
+			if itemResponse.Error != nil {
+				reason = fmt.Sprintf("[%s] %s", itemResponse.Error.Type, itemResponse.Error.Reason)
+			}
+
+			if err := item.AckFunc(fmt.Errorf("%s; dead letter failure: %s", item.err, reason)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		sdk.Logger(ctx).Warn().Err(item.err).Msgf("document stored in dead letter index %s", d.config.DeadLetterIndex)
+
+		if err := item.AckFunc(nil); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
-func (d *Destination) Teardown(context.Context) error {
-	return nil // No close routine needed
-}
+// Teardown flushes buffered operations, waiting for them no longer than the drain timeout.
+// Records which could not be written in time are nacked.
+func (d *Destination) Teardown(ctx context.Context) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
-// prepareBulkRequestPayload converts all pending operations into a valid Elasticsearch Bulk API request.
-func (d *Destination) prepareBulkRequestPayload(ctx context.Context) (*bytes.Buffer, error) {
-	data := &bytes.Buffer{}
+	drainCtx, cancel := context.WithTimeout(ctx, d.config.DrainTimeout)
+	defer cancel()
 
-	for _, item := range d.operationsQueue {
-		record := item.Record
-		action := record.Metadata["action"]
+	var nackErr error
 
-		var key string
-		if record.Key != nil {
-			key = string(record.Key.Bytes())
+	if err := d.drain(drainCtx); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%q of %s exceeded", ConfigKeyDrainTimeout, d.config.DrainTimeout)
 		}
 
-		if key == "" {
-			action = internal.OperationInsert
-		} else if action == "" {
-			action = internal.OperationUpdate
+		sdk.Logger(ctx).Warn().Err(err).Msgf("%d buffered records could not be flushed", d.pendingAcks.Len())
+
+		nackErr = d.pendingAcks.NackAll(fmt.Errorf("record could not be flushed before teardown: %w", err))
+
+		if d.workers != nil {
+			d.workers.Abort()
 		}
+	}
 
-		switch action {
-		case internal.OperationInsert:
-			if err := d.writeInsertOperation(data, record); err != nil {
-				return nil, err
-			}
+	if d.workers != nil {
+		d.workers.Close()
+		d.workers = nil
+	}
 
-		case internal.OperationUpdate:
-			if err := d.writeUpsertOperation(key, data, record); err != nil {
-				return nil, err
-			}
+	if d.client != nil {
+		d.client.CloseIdleConnections()
+	}
 
-		case internal.OperationDelete:
-			if err := d.writeDeleteOperation(key, data); err != nil {
-				return nil, err
-			}
+	if d.deadLetterClient != nil {
+		d.deadLetterClient.CloseIdleConnections()
+	}
 
-		default:
-			sdk.Logger(ctx).Warn().Msgf("unsupported action: %s", action)
+	if d.metricsServer != nil {
+		if err := d.metricsServer.Close(); err != nil {
+			sdk.Logger(ctx).Warn().Err(err).Msg("metrics listener could not be closed")
+		}
+
+		d.metricsServer = nil
+	}
+
+	return nackErr
+}
+
+// drain sends all buffered operations and waits until the bulk workers execute them, or the context is done.
+func (d *Destination) drain(ctx context.Context) error {
+	if err := d.flushOperationsQueue(ctx); err != nil {
+		return err
+	}
+
+	workers := d.workers
+	if workers == nil {
+		return nil
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- workers.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// prepareBulkRequestPayload converts given operations into a valid Elasticsearch Bulk API request.
+func (d *Destination) prepareBulkRequestPayload(ctx context.Context, operations BufferQueue) (data *bytes.Buffer, err error) {
+	ctx, span := d.startSpan(ctx, "prepareBulkRequestPayload", attribute.Int("records", operations.Len()))
+	defer func() {
+		if data != nil {
+			span.SetAttributes(attribute.Int("bytes", data.Len()))
+		}
+		endSpan(span, err)
+	}()
+
+	data = &bytes.Buffer{}
+
+	for _, item := range operations {
+		// Reuse the payload encoded when the operation was queued
+		if item.payload != nil {
+			data.Write(item.payload)
 
 			continue
 		}
+
+		if err := d.writeOperation(ctx, data, item.Record); err != nil {
+			return nil, err
+		}
 	}
 
 	return data, nil
 }
 
+// errOperationSkipped is returned when a Record does not translate into any Bulk API operation.
+var errOperationSkipped = errors.New("operation skipped")
+
+// writeOperation adds the Bulk API request lines of a single Record into Bulk API request.
+// Returns errOperationSkipped when the Record does not translate into any operation.
+func (d *Destination) writeOperation(ctx context.Context, data *bytes.Buffer, record sdk.Record) error {
+	// Debezium change events define the action and the Document on their own
+	if d.config.DebeziumEnvelope {
+		if envelope, ok := parseDebeziumEnvelope(record.Payload); ok {
+			return d.writeDebeziumOperation(ctx, data, record, envelope)
+		}
+	}
+
+	action := record.Metadata["action"]
+	key := recordKey(record)
+
+	if key == "" {
+		action = internal.OperationInsert
+	} else if action == "" {
+		action = internal.OperationUpdate
+	}
+
+	// Reshape the payload of written Documents
+	if action != internal.OperationDelete && !d.config.FieldTransformations.Empty() {
+		record.Payload = d.config.FieldTransformations.Apply(record.Payload)
+	}
+
+	switch action {
+	case internal.OperationInsert:
+		if key == "" {
+			return d.writeKeylessOperation(data, record)
+		}
+
+		return d.writeInsertOperation(data, record)
+
+	case internal.OperationUpdate:
+		return d.writeUpsertOperation(key, data, record)
+
+	case internal.OperationDelete:
+		return d.writeDeleteOperation(key, 0, data)
+
+	default:
+		sdk.Logger(ctx).Warn().Msgf("unsupported action: %s", action)
+
+		return errOperationSkipped
+	}
+}
+
+// recordKey returns Record's Key as a string, or an empty string when the Key is not set
+func recordKey(record sdk.Record) string {
+	if record.Key == nil {
+		return ""
+	}
+
+	return string(record.Key.Bytes())
+}
+
 // writeInsertOperation adds create new Document without ID request into Bulk API request
 func (d *Destination) writeInsertOperation(data *bytes.Buffer, item sdk.Record) error {
 	jsonEncoder := json.NewEncoder(data)
@@ -279,6 +686,21 @@ func (d *Destination) writeInsertOperation(data *bytes.Buffer, item sdk.Record)
 	return nil
 }
 
+// writeKeylessOperation adds create new Document request of a Record without Key into Bulk API request.
+// When content hash IDs are enabled, the Document is identified by its content, so redelivered Records overwrite it.
+func (d *Destination) writeKeylessOperation(data *bytes.Buffer, item sdk.Record) error {
+	if !d.config.ContentHashID {
+		return d.writeInsertOperation(data, item)
+	}
+
+	key, err := contentHashID(item.Payload, d.config.ContentHashFields)
+	if err != nil {
+		return fmt.Errorf("failed to prepare content hash ID: %w", err)
+	}
+
+	return d.writeIndexOperation(key, 0, data, item)
+}
+
 // writeUpsertOperation adds upsert a Document with ID request into Bulk API request
 func (d *Destination) writeUpsertOperation(key string, data *bytes.Buffer, item sdk.Record) error {
 	jsonEncoder := json.NewEncoder(data)
@@ -302,12 +724,35 @@ func (d *Destination) writeUpsertOperation(key string, data *bytes.Buffer, item
 	return nil
 }
 
+// writeIndexOperation adds create or replace a Document with ID request into Bulk API request
+func (d *Destination) writeIndexOperation(key string, version uint64, data *bytes.Buffer, item sdk.Record) error {
+	jsonEncoder := json.NewEncoder(data)
+
+	// Prepare data
+	metadata, payload, err := d.client.PrepareIndexOperation(key, item, version)
+	if err != nil {
+		return fmt.Errorf("failed to prepare metadata with key=%s: %w", key, err)
+	}
+
+	// Write metadata
+	if err := jsonEncoder.Encode(metadata); err != nil {
+		return fmt.Errorf("failed to prepare metadata with key=%s: %w", key, err)
+	}
+
+	// Write payload
+	if err := jsonEncoder.Encode(payload); err != nil {
+		return fmt.Errorf("failed to prepare data with key=%s: %w", key, err)
+	}
+
+	return nil
+}
+
 // writeDeleteOperation adds delete a Document by ID request into Bulk API request
-func (d *Destination) writeDeleteOperation(key string, data *bytes.Buffer) error {
+func (d *Destination) writeDeleteOperation(key string, version uint64, data *bytes.Buffer) error {
 	jsonEncoder := json.NewEncoder(data)
 
 	// Prepare data
-	metadata, err := d.client.PrepareDeleteOperation(key)
+	metadata, err := d.client.PrepareDeleteOperation(key, version)
 	if err != nil {
 		return fmt.Errorf("failed to prepare metadata with key=%s: %w", key, err)
 	}
@@ -320,8 +765,46 @@ func (d *Destination) writeDeleteOperation(key string, data *bytes.Buffer) error
 	return nil
 }
 
+// writeDeadLetterOperation adds create new dead letter Document without ID request into Bulk API request
+func (d *Destination) writeDeadLetterOperation(data *bytes.Buffer, document []byte) error {
+	jsonEncoder := json.NewEncoder(data)
+
+	// Prepare data
+	metadata, payload, err := d.deadLetterClient.PrepareCreateOperation(sdk.Record{
+		Payload: sdk.RawData(document),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prepare dead letter metadata: %w", err)
+	}
+
+	// Write metadata
+	if err := jsonEncoder.Encode(metadata); err != nil {
+		return fmt.Errorf("failed to prepare dead letter metadata: %w", err)
+	}
+
+	// Write payload
+	if err := jsonEncoder.Encode(payload); err != nil {
+		return fmt.Errorf("failed to prepare dead letter data: %w", err)
+	}
+
+	return nil
+}
+
 // executeBulkRequest executes Bulk API request and parses the response
-func (d *Destination) executeBulkRequest(ctx context.Context, data *bytes.Buffer) (bulkResponse, error) {
+func (d *Destination) executeBulkRequest(
+	ctx context.Context,
+	esClient client,
+	data *bytes.Buffer,
+) (response bulkResponse, err error) {
+	ctx, span := d.startSpan(ctx, "executeBulkRequest", attribute.Int("bytes", data.Len()))
+	defer func() {
+		span.SetAttributes(
+			attribute.Int("items", len(response.Items)),
+			attribute.Int("failures", response.failuresCount()),
+		)
+		endSpan(span, err)
+	}()
+
 	// Check if there is any job to do
 	if data.Len() < 1 {
 		sdk.Logger(ctx).Info().Msg("no operations to execute in bulk, skipping")
@@ -332,7 +815,7 @@ func (d *Destination) executeBulkRequest(ctx context.Context, data *bytes.Buffer
 	defer data.Reset()
 
 	// Execute the request
-	responseBody, err := d.client.Bulk(ctx, bytes.NewReader(data.Bytes()))
+	responseBody, err := d.sendBulkRequest(ctx, esClient, data.Bytes())
 	if err != nil {
 		return bulkResponse{}, fmt.Errorf("bulk request failure: %w", err)
 	}
@@ -348,10 +831,34 @@ func (d *Destination) executeBulkRequest(ctx context.Context, data *bytes.Buffer
 	}
 
 	// Read individual errors
-	var response bulkResponse
 	if err := json.Unmarshal(bodyContents, &response); err != nil {
 		return bulkResponse{}, fmt.Errorf("bulk response failure: could not read the response: %w", err)
 	}
 
 	return response, nil
 }
+
+// sendBulkRequest sends Bulk API request, retrying it on transport failures and transient cluster errors
+func (d *Destination) sendBulkRequest(ctx context.Context, esClient client, data []byte) (io.ReadCloser, error) {
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		responseBody, err := esClient.Bulk(ctx, bytes.NewReader(data))
+		d.metrics.observeBulkRequest(len(data), time.Since(start), err)
+
+		if err == nil {
+			return responseBody, nil
+		}
+
+		if attempt > int(d.config.Retries) || ctx.Err() != nil || !internal.IsRetryableError(err) {
+			return nil, err
+		}
+
+		sdk.Logger(ctx).Warn().Err(err).Msgf("bulk request failure, retrying %d of %d", attempt, d.config.Retries)
+
+		d.metrics.observeRetries("request", 1)
+
+		if err := d.config.retryBackoff().Wait(ctx, attempt); err != nil {
+			return nil, err
+		}
+	}
+}
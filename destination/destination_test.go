@@ -23,12 +23,15 @@ import (
 	"io"
 	"net/http"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/jaswdr/faker"
 	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -48,6 +51,162 @@ func TestDestination_GetClient(t *testing.T) {
 	require.Same(t, clientMock, destination.GetClient())
 }
 
+func TestDestination_WriteAsync(t *testing.T) {
+	fakerInstance := faker.New()
+
+	t.Run("Flushes when Bulk Max Bytes limit would be exceeded", func(t *testing.T) {
+		var (
+			// Each operation is encoded as two quoted lines of 4+2+1 bytes each, 14 bytes in total
+			record1 = sdk.Record{Payload: sdk.RawData("1111")}
+			record2 = sdk.Record{Payload: sdk.RawData("2222")}
+			record3 = sdk.Record{Payload: sdk.RawData("3333")}
+		)
+
+		var bulkRequests []string
+
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return string(item.Payload.Bytes()), string(item.Payload.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				bulkRequest, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				bulkRequests = append(bulkRequests, string(bulkRequest))
+
+				items := make([]bulkResponseItems, bytes.Count(bulkRequest, []byte("\n"))/2)
+				for n := range items {
+					items[n].Create = &bulkResponseItem{
+						Status: http.StatusCreated,
+					}
+				}
+
+				data, err := json.Marshal(bulkResponse{
+					Items: items,
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:     10,
+				BulkMaxBytes: 30,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), record1, successfulAckFunc(t)))
+		require.NoError(t, destination.WriteAsync(context.Background(), record2, successfulAckFunc(t)))
+		require.Len(t, esClientMock.BulkCalls(), 0)
+		require.Equal(t, uint64(28), destination.operationsQueueSize)
+
+		require.NoError(t, destination.WriteAsync(context.Background(), record3, successfulAckFunc(t)))
+		require.Len(t, esClientMock.BulkCalls(), 1)
+		require.Equal(t, "\"1111\"\n\"1111\"\n\"2222\"\n\"2222\"\n", bulkRequests[0])
+		require.Equal(t, 1, destination.operationsQueue.Len())
+		require.Equal(t, uint64(14), destination.operationsQueueSize)
+
+		require.NoError(t, destination.Flush(context.Background()))
+		require.Len(t, esClientMock.BulkCalls(), 2)
+		require.Equal(t, "\"3333\"\n\"3333\"\n", bulkRequests[1])
+		require.Len(t, esClientMock.PrepareCreateOperationCalls(), 3)
+	})
+
+	t.Run("Fails a single Record exceeding Bulk Max Bytes limit", func(t *testing.T) {
+		var (
+			key     = fakerInstance.Lorem().Word()
+			payload = fakerInstance.Lorem().Sentence(6)
+		)
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item sdk.Record) (interface{}, interface{}, error) {
+				return key, string(item.Payload.Bytes()), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:     10,
+				BulkMaxBytes: 10,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		expectedSize := len(fmt.Sprintf("%q\n%q\n", key, payload))
+
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{
+			Key:     sdk.RawData(key),
+			Payload: sdk.RawData(payload),
+		}, unsuccessfulAckFunc(t, fmt.Sprintf(
+			"item with key=%s failure: encoded size of %d bytes exceeds %q limit of %d bytes",
+			key,
+			expectedSize,
+			ConfigKeyBulkMaxBytes,
+			10,
+		))))
+		require.True(t, destination.operationsQueue.Empty())
+		require.Equal(t, uint64(0), destination.operationsQueueSize)
+		require.Len(t, esClientMock.BulkCalls(), 0)
+	})
+
+	t.Run("Transforms payload of written Documents", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item sdk.Record) (interface{}, interface{}, error) {
+				return key, item.Payload, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize: 10,
+				FieldTransformations: fieldTransformations{
+					Rename: []fieldRename{{From: "name", To: "fullName"}},
+				},
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		record := sdk.Record{
+			Key:     sdk.RawData("1"),
+			Payload: sdk.RawData(`{"name":"John"}`),
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), record, successfulAckFunc(t)))
+		require.Len(t, esClientMock.PrepareUpsertOperationCalls(), 1)
+		require.Equal(t, sdk.StructuredData{"fullName": "John"}, esClientMock.PrepareUpsertOperationCalls()[0].Item.Payload)
+		require.Equal(t, record, destination.operationsQueue[0].Record)
+	})
+
+	t.Run("Acks Record with unsupported action without queuing it", func(t *testing.T) {
+		esClientMock := clientMock{}
+
+		destination := Destination{
+			config: Config{
+				BulkSize: 10,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		record := sdk.Record{
+			Metadata: map[string]string{"action": "truncate"},
+			Key:      sdk.RawData("1"),
+			Payload:  sdk.RawData(`{"name":"John"}`),
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), record, successfulAckFunc(t)))
+		require.True(t, destination.operationsQueue.Empty())
+		require.Equal(t, 0, destination.pendingAcks.Len())
+	})
+}
+
 func TestDestination_Flush(t *testing.T) {
 	fakerInstance := faker.New()
 
@@ -226,12 +385,12 @@ func TestDestination_Flush(t *testing.T) {
 							},
 							{
 								Create: &bulkResponseItem{
-									Status: http.StatusInternalServerError,
+									Status: http.StatusServiceUnavailable,
 								},
 							},
 							{
 								Create: &bulkResponseItem{
-									Status: http.StatusInternalServerError,
+									Status: http.StatusServiceUnavailable,
 								},
 							},
 						},
@@ -253,7 +412,7 @@ func TestDestination_Flush(t *testing.T) {
 						Items: []bulkResponseItems{
 							{
 								Create: &bulkResponseItem{
-									Status: http.StatusInternalServerError,
+									Status: http.StatusServiceUnavailable,
 								},
 							},
 							{
@@ -278,7 +437,7 @@ func TestDestination_Flush(t *testing.T) {
 						Items: []bulkResponseItems{
 							{
 								Create: &bulkResponseItem{
-									Status: http.StatusInternalServerError,
+									Status: http.StatusServiceUnavailable,
 									Error: &bulkResponseItemError{
 										Type:     "foo",
 										Reason:   "bar",
@@ -329,6 +488,412 @@ func TestDestination_Flush(t *testing.T) {
 		require.Len(t, esClientMock.BulkCalls(), 3)
 	})
 
+	t.Run("Does not retry failures which are not retryable", func(t *testing.T) {
+		var (
+			operationMetadata = fakerInstance.Lorem().Sentence(6)
+			operationPayload  = fakerInstance.Lorem().Sentence(6)
+		)
+
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return operationMetadata, operationPayload, nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				data, err := json.Marshal(bulkResponse{
+					Took:   0,
+					Errors: true,
+					Items: []bulkResponseItems{
+						{
+							Create: &bulkResponseItem{
+								Status: http.StatusBadRequest,
+								Error: &bulkResponseItemError{
+									Type:     "mapper_parsing_exception",
+									Reason:   "failed to parse",
+									CausedBy: json.RawMessage(`"baz"`),
+								},
+							},
+						},
+					},
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize: 1,
+				Retries:  2,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		destination.operationsQueue.Enqueue(&operation{
+			CreatedAt: time.Now(),
+			Record: sdk.Record{
+				CreatedAt: time.Now(),
+				Metadata: map[string]string{
+					"action": internal.OperationInsert,
+				},
+				Payload: sdk.StructuredData{
+					"id": fakerInstance.Int32(),
+				},
+			},
+			AckFunc: unsuccessfulAckFunc(t, fmt.Sprintf("item with key= create failure: [%s] %s: %q", "mapper_parsing_exception", "failed to parse", "baz")),
+		})
+
+		require.NoError(t, destination.Flush(context.Background()))
+		require.Len(t, esClientMock.PrepareCreateOperationCalls(), 1)
+		require.Len(t, esClientMock.BulkCalls(), 1)
+	})
+
+	t.Run("Retries bulk request after transient failure", func(t *testing.T) {
+		bulkFuncConditionsCounter := 0
+
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return fakerInstance.Lorem().Word(), fakerInstance.Lorem().Word(), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				defer func() { bulkFuncConditionsCounter++ }()
+
+				switch bulkFuncConditionsCounter {
+				case 0:
+					return nil, &internal.TransportError{Err: errors.New("connection refused")}
+
+				case 1:
+					return nil, &internal.ResponseError{StatusCode: http.StatusServiceUnavailable, Reason: "503 Service Unavailable"}
+				}
+
+				data, err := json.Marshal(bulkResponse{
+					Items: []bulkResponseItems{
+						{
+							Create: &bulkResponseItem{
+								Status: http.StatusCreated,
+							},
+						},
+					},
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize: 1,
+				Retries:  2,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		destination.operationsQueue.Enqueue(&operation{
+			Record:  sdk.Record{Payload: sdk.StructuredData{"id": fakerInstance.Int32()}},
+			AckFunc: successfulAckFunc(t),
+		})
+
+		require.NoError(t, destination.Flush(context.Background()))
+		require.Len(t, esClientMock.PrepareCreateOperationCalls(), 1)
+		require.Len(t, esClientMock.BulkCalls(), 3)
+	})
+
+	t.Run("Fails without retry when bulk request failure is permanent", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return fakerInstance.Lorem().Word(), fakerInstance.Lorem().Word(), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				return nil, &internal.ResponseError{StatusCode: http.StatusUnauthorized, Type: "security_exception", Reason: "missing authentication credentials"}
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize: 1,
+				Retries:  2,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		destination.operationsQueue.Enqueue(&operation{
+			Record:  sdk.Record{Payload: sdk.StructuredData{"id": fakerInstance.Int32()}},
+			AckFunc: successfulAckFunc(t),
+		})
+
+		require.EqualError(
+			t,
+			destination.Flush(context.Background()),
+			"bulk request failure: [security_exception] missing authentication credentials",
+		)
+		require.Len(t, esClientMock.BulkCalls(), 1)
+	})
+
+	t.Run("Fails when retries limit of bulk request is reached", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return fakerInstance.Lorem().Word(), fakerInstance.Lorem().Word(), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				return nil, &internal.TransportError{Err: errors.New("connection refused")}
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize: 1,
+				Retries:  2,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		destination.operationsQueue.Enqueue(&operation{
+			Record:  sdk.Record{Payload: sdk.StructuredData{"id": fakerInstance.Int32()}},
+			AckFunc: successfulAckFunc(t),
+		})
+
+		require.EqualError(t, destination.Flush(context.Background()), "bulk request failure: connection refused")
+		require.Len(t, esClientMock.BulkCalls(), 3)
+	})
+
+	t.Run("Stores permanently failed Documents in dead letter index", func(t *testing.T) {
+		var (
+			record = sdk.Record{
+				Key:      sdk.RawData("42"),
+				Metadata: map[string]string{"action": internal.OperationUpdate},
+				Payload:  sdk.RawData(`{"id":"not a number"}`),
+			}
+			deadLetters []deadLetterDocument
+		)
+
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item sdk.Record) (interface{}, interface{}, error) {
+				return key, string(item.Payload.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				data, err := json.Marshal(bulkResponse{
+					Errors: true,
+					Items: []bulkResponseItems{
+						{
+							Update: &bulkResponseItem{
+								ID:     "42",
+								Status: http.StatusBadRequest,
+								Error: &bulkResponseItemError{
+									Type:     "mapper_parsing_exception",
+									Reason:   "failed to parse field [id] of type [long]",
+									CausedBy: json.RawMessage(`{"type":"illegal_argument_exception"}`),
+								},
+							},
+						},
+					},
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		deadLetterClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				var document deadLetterDocument
+				require.NoError(t, json.Unmarshal(item.Payload.Bytes(), &document))
+
+				deadLetters = append(deadLetters, document)
+
+				return "dead letter", document, nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				data, err := json.Marshal(bulkResponse{
+					Items: []bulkResponseItems{
+						{
+							Create: &bulkResponseItem{
+								Status: http.StatusCreated,
+							},
+						},
+					},
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Index:           "users",
+				BulkSize:        1,
+				Retries:         2,
+				DeadLetterIndex: "users-dead-letters",
+			},
+			client:           &esClientMock,
+			deadLetterClient: &deadLetterClientMock,
+			operationsQueue:  make(BufferQueue, 0),
+		}
+
+		destination.operationsQueue.Enqueue(&operation{
+			Record:  record,
+			AckFunc: successfulAckFunc(t),
+		})
+
+		require.NoError(t, destination.Flush(context.Background()))
+		require.Len(t, esClientMock.BulkCalls(), 1)
+		require.Len(t, deadLetterClientMock.BulkCalls(), 1)
+		require.Len(t, deadLetters, 1)
+		require.Equal(t, "users", deadLetters[0].Index)
+		require.Equal(t, "update", deadLetters[0].Action)
+		require.Equal(t, "42", deadLetters[0].Key)
+		require.Equal(t, `{"id":"not a number"}`, deadLetters[0].Payload)
+		require.Equal(t, record.Metadata, deadLetters[0].Metadata)
+		require.Equal(t, http.StatusBadRequest, deadLetters[0].Status)
+		require.Equal(t, "mapper_parsing_exception", deadLetters[0].ErrorType)
+		require.Equal(t, "failed to parse field [id] of type [long]", deadLetters[0].Reason)
+		require.Equal(t, `{"type":"illegal_argument_exception"}`, deadLetters[0].CausedBy)
+		require.Equal(t, 1, deadLetters[0].Attempts)
+	})
+
+	t.Run("Fails Record when Document could not be stored in dead letter index", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return "metadata", "payload", nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				data, err := json.Marshal(bulkResponse{
+					Errors: true,
+					Items: []bulkResponseItems{
+						{
+							Create: &bulkResponseItem{
+								Status: http.StatusBadRequest,
+								Error: &bulkResponseItemError{
+									Type:   "mapper_parsing_exception",
+									Reason: "failed to parse",
+								},
+							},
+						},
+					},
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		deadLetterClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return "metadata", "payload", nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				return nil, &internal.ResponseError{StatusCode: http.StatusForbidden, Type: "security_exception", Reason: "unauthorized"}
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:        1,
+				DeadLetterIndex: "dead-letters",
+			},
+			client:           &esClientMock,
+			deadLetterClient: &deadLetterClientMock,
+			operationsQueue:  make(BufferQueue, 0),
+		}
+
+		destination.operationsQueue.Enqueue(&operation{
+			Record: sdk.Record{Payload: sdk.StructuredData{"id": fakerInstance.Int32()}},
+			AckFunc: unsuccessfulAckFunc(
+				t,
+				"item with key= create failure: [mapper_parsing_exception] failed to parse: null; "+
+					"dead letter failure: bulk request failure: [security_exception] unauthorized",
+			),
+		})
+
+		require.NoError(t, destination.Flush(context.Background()))
+		require.Len(t, deadLetterClientMock.BulkCalls(), 1)
+	})
+
+	t.Run("Handles failures according to failure policies", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareDeleteOperationFunc: func(key string, version uint64) (interface{}, error) {
+				return key, nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				data, err := json.Marshal(bulkResponse{
+					Errors: true,
+					Items: []bulkResponseItems{
+						{
+							Delete: &bulkResponseItem{
+								ID:     "1",
+								Status: http.StatusNotFound,
+							},
+						},
+						{
+							Delete: &bulkResponseItem{
+								ID:     "2",
+								Status: http.StatusConflict,
+								Error: &bulkResponseItemError{
+									Type:   "version_conflict_engine_exception",
+									Reason: "version conflict",
+								},
+							},
+						},
+						{
+							Delete: &bulkResponseItem{
+								ID:     "3",
+								Status: http.StatusForbidden,
+								Error: &bulkResponseItemError{
+									Type:   "cluster_block_exception",
+									Reason: "index blocked",
+								},
+							},
+						},
+					},
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:        3,
+				Retries:         2,
+				FailurePolicies: defaultFailurePolicies,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		for n, ackFunc := range []sdk.AckFunc{
+			successfulAckFunc(t),
+			successfulAckFunc(t),
+			unsuccessfulAckFunc(t, "item with key=3 delete failure: [cluster_block_exception] index blocked: null"),
+		} {
+			destination.operationsQueue.Enqueue(&operation{
+				Record: sdk.Record{
+					Key:      sdk.RawData(fmt.Sprintf("%d", n+1)),
+					Metadata: map[string]string{"action": internal.OperationDelete},
+				},
+				AckFunc: ackFunc,
+			})
+		}
+
+		require.NoError(t, destination.Flush(context.Background()))
+		require.Len(t, esClientMock.BulkCalls(), 1)
+	})
+
 	t.Run("Succeeds in the second retry", func(t *testing.T) {
 		var (
 			// Succeeds
@@ -425,12 +990,12 @@ func TestDestination_Flush(t *testing.T) {
 							},
 							{
 								Create: &bulkResponseItem{
-									Status: http.StatusInternalServerError,
+									Status: http.StatusServiceUnavailable,
 								},
 							},
 							{
 								Create: &bulkResponseItem{
-									Status: http.StatusInternalServerError,
+									Status: http.StatusServiceUnavailable,
 								},
 							},
 						},
@@ -452,7 +1017,7 @@ func TestDestination_Flush(t *testing.T) {
 						Items: []bulkResponseItems{
 							{
 								Create: &bulkResponseItem{
-									Status: http.StatusInternalServerError,
+									Status: http.StatusServiceUnavailable,
 								},
 							},
 							{
@@ -522,6 +1087,288 @@ func TestDestination_Flush(t *testing.T) {
 		require.Len(t, esClientMock.PrepareDeleteOperationCalls(), 0)
 		require.Len(t, esClientMock.BulkCalls(), 3)
 	})
+
+	t.Run("Records metrics", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return string(item.Payload.Bytes()), string(item.Payload.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(`{"items":[{"create":{"status":201}},{"create":{"status":201}}]}`)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize: 10,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+			metrics:         newMetrics(),
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("1111")}, successfulAckFunc(t)))
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("2222")}, successfulAckFunc(t)))
+		require.Equal(t, float64(2), testutil.ToFloat64(destination.metrics.bufferDepth))
+
+		require.NoError(t, destination.Flush(context.Background()))
+		require.Equal(t, float64(0), testutil.ToFloat64(destination.metrics.bufferDepth))
+		require.Equal(t, float64(1), testutil.ToFloat64(destination.metrics.bulkRequests.WithLabelValues("success")))
+		require.Equal(t, float64(28), testutil.ToFloat64(destination.metrics.bytesSent))
+		require.Equal(t, float64(2), testutil.ToFloat64(destination.metrics.documents.WithLabelValues("create")))
+	})
+}
+
+func TestDestination_Teardown(t *testing.T) {
+	t.Run("Flushes buffered operations", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return string(item.Payload.Bytes()), string(item.Payload.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(`{"items":[{"create":{"status":201}},{"create":{"status":201}}]}`)), nil
+			},
+
+			CloseIdleConnectionsFunc: func() {},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:     10,
+				DrainTimeout: time.Second,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("1111")}, successfulAckFunc(t)))
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("2222")}, successfulAckFunc(t)))
+		require.Len(t, esClientMock.BulkCalls(), 0)
+
+		require.NoError(t, destination.Teardown(context.Background()))
+		require.Len(t, esClientMock.BulkCalls(), 1)
+		require.True(t, destination.operationsQueue.Empty())
+		require.Equal(t, 0, destination.pendingAcks.Len())
+		require.Len(t, esClientMock.CloseIdleConnectionsCalls(), 1)
+	})
+
+	t.Run("Nacks Records which could not be flushed within Drain Timeout", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return string(item.Payload.Bytes()), string(item.Payload.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				<-ctx.Done()
+
+				return nil, ctx.Err()
+			},
+
+			CloseIdleConnectionsFunc: func() {},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:     10,
+				DrainTimeout: 10 * time.Millisecond,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		expectedError := fmt.Sprintf("record could not be flushed before teardown: %q of 10ms exceeded", ConfigKeyDrainTimeout)
+
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("1111")}, unsuccessfulAckFunc(t, expectedError)))
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("2222")}, unsuccessfulAckFunc(t, expectedError)))
+
+		require.NoError(t, destination.Teardown(context.Background()))
+		require.Len(t, esClientMock.BulkCalls(), 1)
+		require.Equal(t, 0, destination.pendingAcks.Len())
+		require.Len(t, esClientMock.CloseIdleConnectionsCalls(), 1)
+	})
+
+	t.Run("Nacks Records which bulk workers could not execute within Drain Timeout", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item sdk.Record) (interface{}, interface{}, error) {
+				return key, string(item.Payload.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				<-ctx.Done()
+
+				return nil, ctx.Err()
+			},
+
+			CloseIdleConnectionsFunc: func() {},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:     10,
+				DrainTimeout: 10 * time.Millisecond,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+		destination.workers = newBulkWorkers(context.Background(), 2, destination.executeOperations)
+
+		expectedError := fmt.Sprintf("record could not be flushed before teardown: %q of 10ms exceeded", ConfigKeyDrainTimeout)
+
+		for n := 0; n < 4; n++ {
+			record := sdk.Record{
+				Key:     sdk.RawData(fmt.Sprintf("key-%d", n)),
+				Payload: sdk.RawData("1111"),
+			}
+
+			require.NoError(t, destination.WriteAsync(context.Background(), record, unsuccessfulAckFunc(t, expectedError)))
+		}
+
+		require.NoError(t, destination.Teardown(context.Background()))
+		require.Nil(t, destination.workers)
+		require.Equal(t, 0, destination.pendingAcks.Len())
+	})
+
+	t.Run("Acks every Record exactly once when a bulk worker fails", func(t *testing.T) {
+		esClientMock := clientMock{
+			PrepareUpsertOperationFunc: func(key string, item sdk.Record) (interface{}, interface{}, error) {
+				return key, string(item.Payload.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				body, err := io.ReadAll(reader)
+				require.NoError(t, err)
+
+				if bytes.Contains(body, []byte("key-0")) {
+					return nil, &internal.ResponseError{StatusCode: http.StatusBadRequest, Type: "illegal_argument_exception", Reason: "invalid request"}
+				}
+
+				items := make([]bulkResponseItems, bytes.Count(body, []byte("\n"))/2)
+				for n := range items {
+					items[n].Update = &bulkResponseItem{Status: http.StatusOK}
+				}
+
+				data, err := json.Marshal(bulkResponse{Items: items})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+
+			CloseIdleConnectionsFunc: func() {},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:     3,
+				DrainTimeout: time.Second,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+		destination.workers = newBulkWorkers(context.Background(), 2, destination.executeOperations)
+
+		var (
+			mutex sync.Mutex
+			acks  = make(map[string]int)
+		)
+
+		for n := 0; n < 30; n++ {
+			key := fmt.Sprintf("key-%d", n%5)
+
+			record := sdk.Record{
+				Key:      sdk.RawData(key),
+				Payload:  sdk.RawData(fmt.Sprintf("%d", n)),
+				Position: sdk.Position(fmt.Sprintf("%d", n)),
+			}
+
+			_ = destination.WriteAsync(context.Background(), record, func(err error) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				acks[string(record.Position)]++
+
+				// Records are nacked with the failure of the worker, not left over for teardown
+				if err != nil {
+					require.NotContains(t, err.Error(), "before teardown")
+				}
+
+				return nil
+			})
+		}
+
+		_ = destination.Flush(context.Background())
+
+		require.NoError(t, destination.Teardown(context.Background()))
+		require.Equal(t, 0, destination.pendingAcks.Len())
+		require.Len(t, acks, 30)
+
+		for position, calls := range acks {
+			require.Equal(t, 1, calls, "record at position %s was acked %d times", position, calls)
+		}
+	})
+
+	t.Run("Closes idle connections of all clients", func(t *testing.T) {
+		esClientMock := clientMock{
+			CloseIdleConnectionsFunc: func() {},
+		}
+		deadLetterClientMock := clientMock{
+			CloseIdleConnectionsFunc: func() {},
+		}
+
+		destination := Destination{
+			client:           &esClientMock,
+			deadLetterClient: &deadLetterClientMock,
+		}
+
+		require.NoError(t, destination.Teardown(context.Background()))
+		require.Len(t, esClientMock.CloseIdleConnectionsCalls(), 1)
+		require.Len(t, deadLetterClientMock.CloseIdleConnectionsCalls(), 1)
+	})
+}
+
+func TestBulkResponseItem_IsRetryable(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		item      bulkResponseItem
+		retryable bool
+	}{
+		{
+			name:      "Too many requests",
+			item:      bulkResponseItem{Status: http.StatusTooManyRequests},
+			retryable: true,
+		},
+		{
+			name:      "Service unavailable",
+			item:      bulkResponseItem{Status: http.StatusServiceUnavailable},
+			retryable: true,
+		},
+		{
+			name: "Rejected execution",
+			item: bulkResponseItem{
+				Status: http.StatusInternalServerError,
+				Error:  &bulkResponseItemError{Type: "es_rejected_execution_exception"},
+			},
+			retryable: true,
+		},
+		{
+			name: "Mapping error",
+			item: bulkResponseItem{
+				Status: http.StatusBadRequest,
+				Error:  &bulkResponseItemError{Type: "mapper_parsing_exception"},
+			},
+			retryable: false,
+		},
+		{
+			name:      "Internal server error",
+			item:      bulkResponseItem{Status: http.StatusInternalServerError},
+			retryable: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.retryable, tt.item.IsRetryable())
+		})
+	}
 }
 
 func recordsAreEqual(record1, record2 sdk.Record) bool {
@@ -0,0 +1,116 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type failurePolicy = string
+
+// Below is a list of all supported policies of handling failed bulk items.
+const (
+	// failurePolicySuccess acks the Record as successfully written.
+	failurePolicySuccess failurePolicy = "success"
+	// failurePolicySkip acks the Record as handled and logs the failure.
+	failurePolicySkip failurePolicy = "skip"
+	// failurePolicyRetry retries the operation until retries limit is reached.
+	failurePolicyRetry failurePolicy = "retry"
+	// failurePolicyDeadLetter stores the Document in the dead letter index, or fails the Record when it is not set.
+	failurePolicyDeadLetter failurePolicy = "deadLetter"
+	// failurePolicyFail fails the Record.
+	failurePolicyFail failurePolicy = "fail"
+)
+
+// failurePolicies maps bulk item failures to the policy of handling them.
+// Keys are either a status code or an error type, optionally prefixed with the action name and a colon,
+// e.g. "404", "delete:404" or "version_conflict_engine_exception".
+type failurePolicies map[string]failurePolicy
+
+// defaultFailurePolicies is the policies table used unless overridden in the config.
+var defaultFailurePolicies = failurePolicies{
+	"delete:404":                        failurePolicySuccess,
+	"version_conflict_engine_exception": failurePolicySkip,
+	"mapper_parsing_exception":          failurePolicyDeadLetter,
+	"429":                               failurePolicyRetry,
+}
+
+// Resolve returns the policy for the failed bulk item of the given action.
+// Error type takes precedence over status code, and action specific entries take precedence over generic ones.
+// When no entry matches, retryable failures are retried and the rest is dead-lettered.
+func (p failurePolicies) Resolve(action string, item bulkResponseItem) failurePolicy {
+	keys := make([]string, 0, 4)
+
+	if item.Error != nil && item.Error.Type != "" {
+		keys = append(keys, action+":"+item.Error.Type, item.Error.Type)
+	}
+
+	status := strconv.Itoa(item.Status)
+	keys = append(keys, action+":"+status, status)
+
+	for _, key := range keys {
+		if policy, ok := p[key]; ok {
+			return policy
+		}
+	}
+
+	if item.IsRetryable() {
+		return failurePolicyRetry
+	}
+
+	return failurePolicyDeadLetter
+}
+
+// parseFailurePolicies parses comma separated list of "key=policy" entries.
+func parseFailurePolicies(value string) (failurePolicies, error) {
+	policies := make(failurePolicies)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, policy, ok := strings.Cut(entry, "=")
+		key, policy = strings.TrimSpace(key), strings.TrimSpace(policy)
+
+		if !ok || key == "" || strings.HasSuffix(key, ":") {
+			return nil, fmt.Errorf("invalid entry %q, expected format is [action:]status|errorType=policy", entry)
+		}
+
+		switch policy {
+		case failurePolicySuccess, failurePolicySkip, failurePolicyRetry, failurePolicyDeadLetter, failurePolicyFail:
+			policies[key] = policy
+
+		default:
+			return nil, fmt.Errorf(
+				"invalid policy %q of entry %q, must be one of [%s]",
+				policy,
+				key,
+				strings.Join([]failurePolicy{
+					failurePolicySuccess,
+					failurePolicySkip,
+					failurePolicyRetry,
+					failurePolicyDeadLetter,
+					failurePolicyFail,
+				}, ", "),
+			)
+		}
+	}
+
+	return policies, nil
+}
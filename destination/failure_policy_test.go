@@ -0,0 +1,111 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailurePolicies_Resolve(t *testing.T) {
+	policies := failurePolicies{
+		"delete:404":                        failurePolicySuccess,
+		"404":                               failurePolicyFail,
+		"version_conflict_engine_exception": failurePolicySkip,
+		"update:version_conflict_engine_exception": failurePolicyRetry,
+		"400": failurePolicyFail,
+	}
+
+	for _, tt := range []struct {
+		name   string
+		action string
+		item   bulkResponseItem
+		policy failurePolicy
+	}{
+		{
+			name:   "Action specific status",
+			action: "delete",
+			item:   bulkResponseItem{Status: http.StatusNotFound},
+			policy: failurePolicySuccess,
+		},
+		{
+			name:   "Generic status",
+			action: "update",
+			item:   bulkResponseItem{Status: http.StatusNotFound},
+			policy: failurePolicyFail,
+		},
+		{
+			name:   "Action specific error type",
+			action: "update",
+			item: bulkResponseItem{
+				Status: http.StatusConflict,
+				Error:  &bulkResponseItemError{Type: "version_conflict_engine_exception"},
+			},
+			policy: failurePolicyRetry,
+		},
+		{
+			name:   "Error type takes precedence over status",
+			action: "create",
+			item: bulkResponseItem{
+				Status: http.StatusBadRequest,
+				Error:  &bulkResponseItemError{Type: "version_conflict_engine_exception"},
+			},
+			policy: failurePolicySkip,
+		},
+		{
+			name:   "Retryable failure without matching entry",
+			action: "create",
+			item:   bulkResponseItem{Status: http.StatusServiceUnavailable},
+			policy: failurePolicyRetry,
+		},
+		{
+			name:   "Permanent failure without matching entry",
+			action: "create",
+			item:   bulkResponseItem{Status: http.StatusInternalServerError},
+			policy: failurePolicyDeadLetter,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.policy, policies.Resolve(tt.action, tt.item))
+		})
+	}
+}
+
+func TestParseFailurePolicies(t *testing.T) {
+	t.Run("Parses comma separated entries", func(t *testing.T) {
+		policies, err := parseFailurePolicies(" delete:404=success, mapper_parsing_exception = fail,429=retry,")
+
+		require.NoError(t, err)
+		require.Equal(t, failurePolicies{
+			"delete:404":               failurePolicySuccess,
+			"mapper_parsing_exception": failurePolicyFail,
+			"429":                      failurePolicyRetry,
+		}, policies)
+	})
+
+	t.Run("Fails when entry has no policy", func(t *testing.T) {
+		_, err := parseFailurePolicies("404")
+
+		require.EqualError(t, err, `invalid entry "404", expected format is [action:]status|errorType=policy`)
+	})
+
+	t.Run("Fails when policy is unknown", func(t *testing.T) {
+		_, err := parseFailurePolicies("404=ignore")
+
+		require.EqualError(t, err, `invalid policy "ignore" of entry "404", must be one of [success, skip, retry, deadLetter, fail]`)
+	})
+}
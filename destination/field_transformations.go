@@ -0,0 +1,297 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+const (
+	// fieldsNestingFlatten replaces nested objects with dotted keys, e.g. `{"a":{"b":1}}` becomes `{"a.b":1}`
+	fieldsNestingFlatten = "flatten"
+
+	// fieldsNestingExpand replaces dotted keys with nested objects, e.g. `{"a.b":1}` becomes `{"a":{"b":1}}`
+	fieldsNestingExpand = "expand"
+)
+
+type fieldRename struct {
+	From string
+	To   string
+}
+
+type fieldConstant struct {
+	Field string
+	Value string
+}
+
+// fieldTransformations reshapes Record's payload before it is encoded.
+// Transformations are applied in order: nesting, include, exclude, rename and constants.
+// Fields are referenced with dotted paths, e.g. `address.city`.
+type fieldTransformations struct {
+	Nesting   string
+	Include   []string
+	Exclude   []string
+	Rename    []fieldRename
+	Constants []fieldConstant
+}
+
+// Empty returns true when no transformation is configured.
+func (t fieldTransformations) Empty() bool {
+	return t.Nesting == "" &&
+		len(t.Include) == 0 &&
+		len(t.Exclude) == 0 &&
+		len(t.Rename) == 0 &&
+		len(t.Constants) == 0
+}
+
+// Apply returns the transformed payload. Structured payloads and raw payloads containing a JSON object
+// are transformed, any other payload is returned unchanged. The original payload is never modified.
+func (t fieldTransformations) Apply(payload sdk.Data) sdk.Data {
+	var fields map[string]interface{}
+
+	switch typedPayload := payload.(type) {
+	case sdk.StructuredData:
+		fields = copyFields(typedPayload)
+
+	case sdk.RawData:
+		decoder := json.NewDecoder(bytes.NewReader(typedPayload))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&fields); err != nil || fields == nil {
+			return payload
+		}
+
+	default:
+		return payload
+	}
+
+	switch t.Nesting {
+	case fieldsNestingFlatten:
+		fields = flattenFields("", fields, make(map[string]interface{}))
+
+	case fieldsNestingExpand:
+		fields = expandFields(fields)
+	}
+
+	if len(t.Include) > 0 {
+		included := make(map[string]interface{}, len(t.Include))
+
+		for _, path := range t.Include {
+			if value, ok := getFieldPath(fields, path); ok {
+				t.setFieldPath(included, path, value)
+			}
+		}
+
+		fields = included
+	}
+
+	for _, path := range t.Exclude {
+		deleteFieldPath(fields, path)
+	}
+
+	for _, rename := range t.Rename {
+		if value, ok := getFieldPath(fields, rename.From); ok {
+			deleteFieldPath(fields, rename.From)
+			t.setFieldPath(fields, rename.To, value)
+		}
+	}
+
+	for _, constant := range t.Constants {
+		t.setFieldPath(fields, constant.Field, constant.Value)
+	}
+
+	return sdk.StructuredData(fields)
+}
+
+// setFieldPath sets the value of the field, creating missing parent objects.
+// Flattened payloads keep dotted keys as they are.
+func (t fieldTransformations) setFieldPath(fields map[string]interface{}, path string, value interface{}) {
+	if t.Nesting == fieldsNestingFlatten {
+		fields[path] = value
+
+		return
+	}
+
+	parts := strings.Split(path, ".")
+
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := asFields(fields[part])
+		if !ok {
+			child = make(map[string]interface{})
+		}
+
+		fields[part] = child
+		fields = child
+	}
+
+	fields[parts[len(parts)-1]] = value
+}
+
+// getFieldPath returns the value of the field. Dotted keys take precedence over nested objects.
+func getFieldPath(fields map[string]interface{}, path string) (interface{}, bool) {
+	if value, ok := fields[path]; ok {
+		return value, true
+	}
+
+	for i := strings.IndexByte(path, '.'); i >= 0; i = nextDot(path, i) {
+		if child, ok := asFields(fields[path[:i]]); ok {
+			if value, ok := getFieldPath(child, path[i+1:]); ok {
+				return value, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// deleteFieldPath removes the field. Dotted keys take precedence over nested objects.
+func deleteFieldPath(fields map[string]interface{}, path string) {
+	if _, ok := fields[path]; ok {
+		delete(fields, path)
+
+		return
+	}
+
+	for i := strings.IndexByte(path, '.'); i >= 0; i = nextDot(path, i) {
+		if child, ok := asFields(fields[path[:i]]); ok {
+			deleteFieldPath(child, path[i+1:])
+		}
+	}
+}
+
+func nextDot(path string, previous int) int {
+	next := strings.IndexByte(path[previous+1:], '.')
+	if next < 0 {
+		return -1
+	}
+
+	return previous + 1 + next
+}
+
+func flattenFields(prefix string, fields, flattened map[string]interface{}) map[string]interface{} {
+	for key, value := range fields {
+		if child, ok := asFields(value); ok && len(child) > 0 {
+			flattenFields(prefix+key+".", child, flattened)
+
+			continue
+		}
+
+		flattened[prefix+key] = value
+	}
+
+	return flattened
+}
+
+func expandFields(fields map[string]interface{}) map[string]interface{} {
+	expanded := make(map[string]interface{}, len(fields))
+	transformations := fieldTransformations{}
+
+	for key, value := range fields {
+		if child, ok := asFields(value); ok {
+			value = expandFields(child)
+		}
+
+		if existing, ok := asFields(expanded[key]); ok {
+			// Merge with the object created from dotted keys
+			if child, ok := value.(map[string]interface{}); ok {
+				for childKey, childValue := range child {
+					existing[childKey] = childValue
+				}
+
+				continue
+			}
+		}
+
+		transformations.setFieldPath(expanded, key, value)
+	}
+
+	return expanded
+}
+
+// copyFields returns a deep copy of nested objects, so they can be safely modified.
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(fields))
+
+	for key, value := range fields {
+		if child, ok := asFields(value); ok {
+			value = copyFields(child)
+		}
+
+		copied[key] = value
+	}
+
+	return copied
+}
+
+func asFields(value interface{}) (map[string]interface{}, bool) {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		return typedValue, true
+
+	case sdk.StructuredData:
+		return typedValue, true
+
+	default:
+		return nil, false
+	}
+}
+
+func parseFieldRenames(value string) ([]fieldRename, error) {
+	var renames []fieldRename
+
+	for _, entry := range splitConfigList(value) {
+		from, to, ok := strings.Cut(entry, ":")
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected format is from:to", entry)
+		}
+
+		renames = append(renames, fieldRename{From: from, To: to})
+	}
+
+	return renames, nil
+}
+
+func parseFieldConstants(value string) ([]fieldConstant, error) {
+	var constants []fieldConstant
+
+	for _, entry := range splitConfigList(value) {
+		field, fieldValue, ok := strings.Cut(entry, ":")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected format is field:value", entry)
+		}
+
+		constants = append(constants, fieldConstant{Field: field, Value: fieldValue})
+	}
+
+	return constants, nil
+}
+
+// splitConfigList splits comma separated list, skipping empty entries.
+func splitConfigList(value string) []string {
+	var entries []string
+
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,190 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldTransformations_Apply(t *testing.T) {
+	newPayload := func() sdk.StructuredData {
+		return sdk.StructuredData{
+			"id":   1,
+			"name": "John",
+			"address": map[string]interface{}{
+				"city": "Warsaw",
+				"zip":  "00-001",
+			},
+			"secret": "password",
+		}
+	}
+
+	for _, tt := range []struct {
+		name            string
+		transformations fieldTransformations
+		payload         sdk.Data
+		expected        sdk.Data
+	}{
+		{
+			name:            "Flattens nested objects",
+			transformations: fieldTransformations{Nesting: fieldsNestingFlatten},
+			payload:         newPayload(),
+			expected: sdk.StructuredData{
+				"id":           1,
+				"name":         "John",
+				"address.city": "Warsaw",
+				"address.zip":  "00-001",
+				"secret":       "password",
+			},
+		},
+		{
+			name:            "Expands dotted keys",
+			transformations: fieldTransformations{Nesting: fieldsNestingExpand},
+			payload: sdk.StructuredData{
+				"address.city": "Warsaw",
+				"address":      map[string]interface{}{"zip": "00-001"},
+				"geo.location": map[string]interface{}{"lat.value": 52.2},
+			},
+			expected: sdk.StructuredData{
+				"address": map[string]interface{}{
+					"city": "Warsaw",
+					"zip":  "00-001",
+				},
+				"geo": map[string]interface{}{
+					"location": map[string]interface{}{
+						"lat": map[string]interface{}{"value": 52.2},
+					},
+				},
+			},
+		},
+		{
+			name: "Includes and excludes fields",
+			transformations: fieldTransformations{
+				Include: []string{"name", "address", "missing"},
+				Exclude: []string{"address.zip"},
+			},
+			payload: newPayload(),
+			expected: sdk.StructuredData{
+				"name":    "John",
+				"address": map[string]interface{}{"city": "Warsaw"},
+			},
+		},
+		{
+			name: "Renames fields and injects constants",
+			transformations: fieldTransformations{
+				Rename: []fieldRename{
+					{From: "name", To: "fullName"},
+					{From: "address.city", To: "city"},
+				},
+				Constants: []fieldConstant{
+					{Field: "source", Value: "conduit"},
+					{Field: "meta.env", Value: "prod"},
+				},
+				Exclude: []string{"secret"},
+			},
+			payload: newPayload(),
+			expected: sdk.StructuredData{
+				"id":       1,
+				"fullName": "John",
+				"city":     "Warsaw",
+				"address":  map[string]interface{}{"zip": "00-001"},
+				"source":   "conduit",
+				"meta":     map[string]interface{}{"env": "prod"},
+			},
+		},
+		{
+			name: "Keeps dotted keys of flattened payload",
+			transformations: fieldTransformations{
+				Nesting: fieldsNestingFlatten,
+				Rename:  []fieldRename{{From: "address.city", To: "location.city"}},
+				Include: []string{"address.city"},
+			},
+			payload: newPayload(),
+			expected: sdk.StructuredData{
+				"location.city": "Warsaw",
+			},
+		},
+		{
+			name:            "Transforms raw JSON payload",
+			transformations: fieldTransformations{Include: []string{"id", "name"}},
+			payload:         sdk.RawData(`{"id":12345678901234567890,"name":"John","secret":"password"}`),
+			expected: sdk.StructuredData{
+				"id":   json.Number("12345678901234567890"),
+				"name": "John",
+			},
+		},
+		{
+			name:            "Leaves raw payload which is not a JSON object unchanged",
+			transformations: fieldTransformations{Include: []string{"id"}},
+			payload:         sdk.RawData(`plain text`),
+			expected:        sdk.RawData(`plain text`),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.transformations.Apply(tt.payload))
+		})
+	}
+
+	t.Run("Does not modify the original payload", func(t *testing.T) {
+		payload := newPayload()
+
+		fieldTransformations{
+			Exclude:   []string{"address.zip"},
+			Constants: []fieldConstant{{Field: "address.country", Value: "PL"}},
+		}.Apply(payload)
+
+		require.Equal(t, newPayload(), payload)
+	})
+}
+
+func TestParseFieldRenames(t *testing.T) {
+	t.Run("Parses comma separated entries", func(t *testing.T) {
+		renames, err := parseFieldRenames("name:fullName, address.city:city")
+
+		require.NoError(t, err)
+		require.Equal(t, []fieldRename{
+			{From: "name", To: "fullName"},
+			{From: "address.city", To: "city"},
+		}, renames)
+	})
+
+	t.Run("Fails when entry has no target", func(t *testing.T) {
+		_, err := parseFieldRenames("name:")
+
+		require.EqualError(t, err, `invalid entry "name:", expected format is from:to`)
+	})
+}
+
+func TestParseFieldConstants(t *testing.T) {
+	t.Run("Parses values containing colons", func(t *testing.T) {
+		constants, err := parseFieldConstants("source:conduit,url:http://localhost")
+
+		require.NoError(t, err)
+		require.Equal(t, []fieldConstant{
+			{Field: "source", Value: "conduit"},
+			{Field: "url", Value: "http://localhost"},
+		}, constants)
+	})
+
+	t.Run("Fails when entry has no value", func(t *testing.T) {
+		_, err := parseFieldConstants("source")
+
+		require.EqualError(t, err, `invalid entry "source", expected format is field:value`)
+	})
+}
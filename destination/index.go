@@ -0,0 +1,163 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+)
+
+// indexAlreadyExistsErrorTypes are returned when the index was created in the meantime by someone else.
+var indexAlreadyExistsErrorTypes = map[string]bool{
+	"index_already_exists_exception":    true, // v5
+	"resource_already_exists_exception": true, // v6+
+}
+
+// ensureIndex creates the index with configured settings and mappings when it does not exist yet.
+// With index lifecycle management, the first index of the rollover series is created instead.
+// Otherwise, mapping of the existing index is checked against the configured one.
+func (d *Destination) ensureIndex(ctx context.Context) error {
+	exists, err := d.client.IndexExists(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check if index exists: %w", err)
+	}
+
+	if !exists {
+		if d.config.ILMPolicy != "" {
+			err = d.createRolloverIndex(ctx)
+		} else {
+			err = d.client.CreateIndex(ctx, bytes.NewReader(d.config.IndexSettings))
+		}
+
+		var responseErr *internal.ResponseError
+		if err == nil {
+			sdk.Logger(ctx).Info().Str("index", d.config.Index).Msg("index created")
+
+			return nil
+		} else if !errors.As(err, &responseErr) || !indexAlreadyExistsErrorTypes[responseErr.Type] {
+			return fmt.Errorf("failed to create index: %w", err)
+		}
+	}
+
+	return d.checkIndexMapping(ctx)
+}
+
+// checkIndexMapping compares fields of the existing index with the configured mapping.
+// Fields mapped with a different type fail the check, missing fields are only reported.
+func (d *Destination) checkIndexMapping(ctx context.Context) error {
+	if len(d.config.IndexSettings) == 0 {
+		return nil
+	}
+
+	expectedMapping, err := d.config.indexMapping()
+	if err != nil {
+		return err
+	}
+	if expectedMapping == nil {
+		return nil
+	}
+
+	actualMapping, err := d.client.GetMapping(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get index mapping: %w", err)
+	}
+
+	var conflicts, missing []string
+
+	compareMappingProperties("", expectedMapping, actualMapping, &conflicts, &missing)
+
+	if len(missing) > 0 {
+		sdk.Logger(ctx).Warn().
+			Str("index", d.config.Index).
+			Strs("fields", missing).
+			Msg("fields of configured mapping are not mapped in the existing index")
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("mapping of existing index conflicts with the configured one: %s", strings.Join(conflicts, ", "))
+	}
+
+	return nil
+}
+
+// indexMapping returns the mapping definition of configured index settings.
+// The mapping may be nested under the type name, as required by Elasticsearch v5 and v6.
+func (c Config) indexMapping() (map[string]interface{}, error) {
+	var settings struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+
+	if err := json.Unmarshal(c.IndexSettings, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse index settings: %w", err)
+	}
+
+	if typeMapping, ok := settings.Mappings[c.Type].(map[string]interface{}); ok && c.Type != "" {
+		return typeMapping, nil
+	}
+
+	return settings.Mappings, nil
+}
+
+// compareMappingProperties recursively compares properties of expected and actual mappings.
+// Conflicting and missing fields are reported with their full path.
+func compareMappingProperties(path string, expected, actual map[string]interface{}, conflicts, missing *[]string) {
+	expectedProperties, _ := expected["properties"].(map[string]interface{})
+	actualProperties, _ := actual["properties"].(map[string]interface{})
+
+	fields := make([]string, 0, len(expectedProperties))
+	for field := range expectedProperties {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		fieldPath := path + field
+
+		expectedField, _ := expectedProperties[field].(map[string]interface{})
+
+		actualField, ok := actualProperties[field].(map[string]interface{})
+		if !ok {
+			*missing = append(*missing, fieldPath)
+
+			continue
+		}
+
+		if expectedType, actualType := mappingFieldType(expectedField), mappingFieldType(actualField); expectedType != actualType {
+			*conflicts = append(*conflicts, fmt.Sprintf("%s is mapped as %s instead of %s", fieldPath, actualType, expectedType))
+
+			continue
+		}
+
+		compareMappingProperties(fieldPath+".", expectedField, actualField, conflicts, missing)
+	}
+}
+
+// mappingFieldType returns the type of mapped field. Fields with properties and no explicit type are objects.
+func mappingFieldType(field map[string]interface{}) string {
+	if fieldType, ok := field["type"].(string); ok {
+		return fieldType
+	}
+
+	return "object"
+}
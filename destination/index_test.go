@@ -0,0 +1,184 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestination_EnsureIndex(t *testing.T) {
+	const indexSettings = `{
+		"settings": {"number_of_shards": 1},
+		"mappings": {
+			"properties": {
+				"name": {"type": "keyword"},
+				"address": {
+					"properties": {
+						"city": {"type": "keyword"},
+						"zip": {"type": "keyword"}
+					}
+				}
+			}
+		}
+	}`
+
+	t.Run("Creates index when it does not exist", func(t *testing.T) {
+		esClientMock := clientMock{
+			IndexExistsFunc: func(ctx context.Context) (bool, error) {
+				return false, nil
+			},
+
+			CreateIndexFunc: func(ctx context.Context, body io.Reader) error {
+				contents, err := io.ReadAll(body)
+				require.NoError(t, err)
+				require.JSONEq(t, indexSettings, string(contents))
+
+				return nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{IndexSettings: []byte(indexSettings)},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureIndex(context.Background()))
+		require.Len(t, esClientMock.CreateIndexCalls(), 1)
+	})
+
+	t.Run("Checks mapping when index was created in the meantime", func(t *testing.T) {
+		esClientMock := clientMock{
+			IndexExistsFunc: func(ctx context.Context) (bool, error) {
+				return false, nil
+			},
+
+			CreateIndexFunc: func(ctx context.Context, body io.Reader) error {
+				return &internal.ResponseError{
+					StatusCode: http.StatusBadRequest,
+					Type:       "resource_already_exists_exception",
+					Reason:     "index already exists",
+				}
+			},
+
+			GetMappingFunc: func(ctx context.Context) (map[string]interface{}, error) {
+				return map[string]interface{}{
+					"properties": map[string]interface{}{
+						"name": map[string]interface{}{"type": "keyword"},
+					},
+				}, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{IndexSettings: []byte(indexSettings)},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureIndex(context.Background()))
+		require.Len(t, esClientMock.GetMappingCalls(), 1)
+	})
+
+	t.Run("Fails when index could not be created", func(t *testing.T) {
+		esClientMock := clientMock{
+			IndexExistsFunc: func(ctx context.Context) (bool, error) {
+				return false, nil
+			},
+
+			CreateIndexFunc: func(ctx context.Context, body io.Reader) error {
+				return &internal.ResponseError{
+					StatusCode: http.StatusBadRequest,
+					Type:       "mapper_parsing_exception",
+					Reason:     "no handler for type [keywords] declared on field [name]",
+				}
+			},
+		}
+
+		destination := Destination{
+			config: Config{IndexSettings: []byte(indexSettings)},
+			client: &esClientMock,
+		}
+
+		require.EqualError(
+			t,
+			destination.ensureIndex(context.Background()),
+			"failed to create index: [mapper_parsing_exception] no handler for type [keywords] declared on field [name]",
+		)
+	})
+
+	t.Run("Fails when existing index has conflicting mapping", func(t *testing.T) {
+		esClientMock := clientMock{
+			IndexExistsFunc: func(ctx context.Context) (bool, error) {
+				return true, nil
+			},
+
+			GetMappingFunc: func(ctx context.Context) (map[string]interface{}, error) {
+				return map[string]interface{}{
+					"properties": map[string]interface{}{
+						"name": map[string]interface{}{"type": "text"},
+						"address": map[string]interface{}{
+							"properties": map[string]interface{}{
+								"zip": map[string]interface{}{"type": "long"},
+							},
+						},
+					},
+				}, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{IndexSettings: []byte(indexSettings)},
+			client: &esClientMock,
+		}
+
+		require.EqualError(
+			t,
+			destination.ensureIndex(context.Background()),
+			"mapping of existing index conflicts with the configured one: address.zip is mapped as long instead of keyword, name is mapped as text instead of keyword",
+		)
+		require.Len(t, esClientMock.CreateIndexCalls(), 0)
+	})
+
+	t.Run("Checks mapping nested under the type name", func(t *testing.T) {
+		esClientMock := clientMock{
+			IndexExistsFunc: func(ctx context.Context) (bool, error) {
+				return true, nil
+			},
+
+			GetMappingFunc: func(ctx context.Context) (map[string]interface{}, error) {
+				return map[string]interface{}{
+					"properties": map[string]interface{}{
+						"name": map[string]interface{}{"type": "keyword"},
+					},
+				}, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Type:          "user",
+				IndexSettings: []byte(`{"mappings":{"user":{"properties":{"name":{"type":"keyword"}}}}}`),
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureIndex(context.Background()))
+	})
+}
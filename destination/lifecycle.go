@@ -0,0 +1,153 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// ensureLifecyclePolicy creates or updates the index lifecycle policy, so the configuration is the source of truth.
+func (d *Destination) ensureLifecyclePolicy(ctx context.Context) error {
+	policy, err := json.Marshal(d.config.lifecyclePolicy())
+	if err != nil {
+		return fmt.Errorf("failed to prepare lifecycle policy: %w", err)
+	}
+
+	if err := d.client.PutLifecyclePolicy(ctx, d.config.ILMPolicy, bytes.NewReader(policy)); err != nil {
+		return fmt.Errorf("failed to put lifecycle policy %q: %w", d.config.ILMPolicy, err)
+	}
+
+	sdk.Logger(ctx).Info().Str("policy", d.config.ILMPolicy).Msg("lifecycle policy updated")
+
+	return nil
+}
+
+// createRolloverIndex creates the first index of the rollover series with the configured index as its write alias.
+// Index settings and mappings are applied to this index when configured.
+func (d *Destination) createRolloverIndex(ctx context.Context) error {
+	body := make(map[string]interface{})
+
+	if len(d.config.IndexSettings) > 0 {
+		if err := json.Unmarshal(d.config.IndexSettings, &body); err != nil {
+			return fmt.Errorf("failed to parse index settings: %w", err)
+		}
+	}
+
+	settings, ok := body["settings"].(map[string]interface{})
+	if !ok {
+		settings = make(map[string]interface{})
+		body["settings"] = settings
+	}
+
+	for key, value := range d.config.lifecycleSettings() {
+		settings[key] = value
+	}
+
+	aliases, ok := body["aliases"].(map[string]interface{})
+	if !ok {
+		aliases = make(map[string]interface{})
+		body["aliases"] = aliases
+	}
+
+	aliases[d.config.Index] = map[string]interface{}{
+		"is_write_index": true,
+	}
+
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to prepare rollover index: %w", err)
+	}
+
+	return d.client.CreateRolloverIndex(ctx, bytes.NewReader(encodedBody))
+}
+
+// lifecyclePolicy returns the body of the index lifecycle policy.
+// The hot phase rolls the index over, while warm and delete phases are added only when configured.
+func (c Config) lifecyclePolicy() map[string]interface{} {
+	rollover := make(map[string]interface{})
+
+	if c.ILMRolloverMaxAge != "" {
+		rollover["max_age"] = c.ILMRolloverMaxAge
+	}
+	if c.ILMRolloverMaxSize != "" {
+		rollover["max_size"] = c.ILMRolloverMaxSize
+	}
+	if c.ILMRolloverMaxDocs > 0 {
+		rollover["max_docs"] = c.ILMRolloverMaxDocs
+	}
+
+	phases := map[string]interface{}{
+		"hot": map[string]interface{}{
+			"actions": map[string]interface{}{
+				"rollover": rollover,
+			},
+		},
+	}
+
+	if c.ILMWarmMinAge != "" {
+		phases["warm"] = map[string]interface{}{
+			"min_age": c.ILMWarmMinAge,
+			"actions": map[string]interface{}{
+				"forcemerge": map[string]interface{}{
+					"max_num_segments": 1,
+				},
+			},
+		}
+	}
+
+	if c.ILMDeleteMinAge != "" {
+		phases["delete"] = map[string]interface{}{
+			"min_age": c.ILMDeleteMinAge,
+			"actions": map[string]interface{}{
+				"delete": map[string]interface{}{},
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"policy": map[string]interface{}{
+			"phases": phases,
+		},
+	}
+}
+
+// lifecycleIndexTemplate returns the index template applying the lifecycle policy to indices of the rollover series.
+func (c Config) lifecycleIndexTemplate() map[string]interface{} {
+	template := map[string]interface{}{
+		"index_patterns": []string{c.Index + "-*"},
+	}
+
+	if c.legacyTemplates() {
+		template["settings"] = c.lifecycleSettings()
+	} else {
+		template["template"] = map[string]interface{}{
+			"settings": c.lifecycleSettings(),
+		}
+	}
+
+	return template
+}
+
+func (c Config) lifecycleSettings() map[string]interface{} {
+	return map[string]interface{}{
+		"index.lifecycle.name":           c.ILMPolicy,
+		"index.lifecycle.rollover_alias": c.Index,
+	}
+}
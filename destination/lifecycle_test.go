@@ -0,0 +1,155 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestination_EnsureLifecyclePolicy(t *testing.T) {
+	t.Run("Puts policy with configured phases", func(t *testing.T) {
+		esClientMock := clientMock{
+			PutLifecyclePolicyFunc: func(ctx context.Context, name string, body io.Reader) error {
+				contents, err := io.ReadAll(body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{
+					"policy": {
+						"phases": {
+							"hot": {"actions": {"rollover": {"max_age": "1d", "max_docs": 1000000}}},
+							"warm": {"min_age": "7d", "actions": {"forcemerge": {"max_num_segments": 1}}},
+							"delete": {"min_age": "30d", "actions": {"delete": {}}}
+						}
+					}
+				}`, string(contents))
+
+				return nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				ILMPolicy:          "users-policy",
+				ILMRolloverMaxAge:  "1d",
+				ILMRolloverMaxDocs: 1_000_000,
+				ILMWarmMinAge:      "7d",
+				ILMDeleteMinAge:    "30d",
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureLifecyclePolicy(context.Background()))
+		require.Len(t, esClientMock.PutLifecyclePolicyCalls(), 1)
+		require.Equal(t, "users-policy", esClientMock.PutLifecyclePolicyCalls()[0].Name)
+	})
+}
+
+func TestDestination_EnsureIndex_Rollover(t *testing.T) {
+	t.Run("Bootstraps the first index with write alias", func(t *testing.T) {
+		esClientMock := clientMock{
+			IndexExistsFunc: func(ctx context.Context) (bool, error) {
+				return false, nil
+			},
+
+			CreateRolloverIndexFunc: func(ctx context.Context, body io.Reader) error {
+				contents, err := io.ReadAll(body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{
+					"settings": {
+						"number_of_shards": 1,
+						"index.lifecycle.name": "users-policy",
+						"index.lifecycle.rollover_alias": "users"
+					},
+					"mappings": {"properties": {"name": {"type": "keyword"}}},
+					"aliases": {"users": {"is_write_index": true}}
+				}`, string(contents))
+
+				return nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Index:         "users",
+				ILMPolicy:     "users-policy",
+				IndexSettings: []byte(`{"settings":{"number_of_shards":1},"mappings":{"properties":{"name":{"type":"keyword"}}}}`),
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureIndex(context.Background()))
+		require.Len(t, esClientMock.CreateRolloverIndexCalls(), 1)
+	})
+
+	t.Run("Does not bootstrap when write alias exists", func(t *testing.T) {
+		esClientMock := clientMock{
+			IndexExistsFunc: func(ctx context.Context) (bool, error) {
+				return true, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Index:     "users",
+				ILMPolicy: "users-policy",
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureIndex(context.Background()))
+	})
+}
+
+func TestConfig_LifecycleIndexTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		version  elasticsearch.Version
+		template map[string]interface{}
+	}{
+		{
+			version: elasticsearch.Version6,
+			template: map[string]interface{}{
+				"index_patterns": []string{"users-*"},
+				"settings": map[string]interface{}{
+					"index.lifecycle.name":           "users-policy",
+					"index.lifecycle.rollover_alias": "users",
+				},
+			},
+		},
+		{
+			version: elasticsearch.Version8,
+			template: map[string]interface{}{
+				"index_patterns": []string{"users-*"},
+				"template": map[string]interface{}{
+					"settings": map[string]interface{}{
+						"index.lifecycle.name":           "users-policy",
+						"index.lifecycle.rollover_alias": "users",
+					},
+				},
+			},
+		},
+	} {
+		config := Config{
+			Version:   tt.version,
+			Index:     "users",
+			ILMPolicy: "users-policy",
+		}
+
+		require.Equal(t, tt.template, config.lifecycleIndexTemplate())
+	}
+}
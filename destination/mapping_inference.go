@@ -0,0 +1,299 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// inferredKeywordMaxLength is the maximum length of strings mapped as `keyword`, longer ones are mapped as `text`.
+// It matches `ignore_above` of strings mapped dynamically by Elasticsearch.
+const inferredKeywordMaxLength = 256
+
+// inferredDateLayouts are layouts of strings mapped as `date`.
+// All of them are supported by the default `strict_date_optional_time` format.
+var inferredDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// sampleMappingRecord stores the payload of structured Record for mapping inference,
+// until the configured number of samples is collected. Raw payloads are sampled when field transformations
+// turn them into structured ones.
+func (d *Destination) sampleMappingRecord(record sdk.Record) {
+	if d.mappingInferred || uint64(len(d.mappingSamples)) >= d.config.MappingInferenceSamples {
+		return
+	}
+
+	payload := record.Payload
+
+	// Sampled payload must match the indexed one
+	if d.config.DebeziumEnvelope {
+		if envelope, ok := parseDebeziumEnvelope(payload); ok {
+			payload = sdk.StructuredData(envelope.After)
+		}
+	}
+
+	if !d.config.FieldTransformations.Empty() {
+		payload = d.config.FieldTransformations.Apply(payload)
+	}
+
+	if payload, ok := payload.(sdk.StructuredData); ok {
+		d.mappingSamples = append(d.mappingSamples, payload)
+	}
+}
+
+// createInferredIndex creates the index with the mapping inferred from sampled Records.
+// It is done once, before the first flush, which ends sampling even if fewer Records than configured were sampled.
+// The index is left unchanged when it already exists.
+func (d *Destination) createInferredIndex(ctx context.Context) error {
+	samples := d.mappingSamples
+
+	d.mappingInferred = true
+	d.mappingSamples = nil
+
+	if len(samples) == 0 {
+		sdk.Logger(ctx).Warn().Msg("no structured Records sampled, mapping was not inferred")
+
+		return nil
+	}
+
+	if uint64(len(samples)) < d.config.MappingInferenceSamples {
+		sdk.Logger(ctx).Warn().
+			Int("samples", len(samples)).
+			Uint64("configured", d.config.MappingInferenceSamples).
+			Msg("fewer Records sampled before the first flush than configured")
+	}
+
+	mapping := inferMapping(samples)
+
+	encodedMapping, err := json.Marshal(mapping)
+	if err != nil {
+		return fmt.Errorf("failed to encode inferred mapping: %w", err)
+	}
+
+	sdk.Logger(ctx).Info().
+		Int("samples", len(samples)).
+		RawJSON("mapping", encodedMapping).
+		Msg("mapping inferred")
+
+	exists, err := d.client.IndexExists(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check if index exists: %w", err)
+	}
+	if exists {
+		sdk.Logger(ctx).Warn().Str("index", d.config.Index).Msg("index already exists, inferred mapping was not applied")
+
+		return nil
+	}
+
+	var body map[string]interface{}
+
+	// Versions 5 and 6 require the mapping to be nested under the type name
+	if d.config.Type != "" && d.config.legacyTemplates() {
+		body = map[string]interface{}{"mappings": map[string]interface{}{d.config.Type: mapping}}
+	} else {
+		body = map[string]interface{}{"mappings": mapping}
+	}
+
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode inferred mapping: %w", err)
+	}
+
+	if err := d.client.CreateIndex(ctx, bytes.NewReader(encodedBody)); err != nil {
+		return fmt.Errorf("failed to create index with inferred mapping: %w", err)
+	}
+
+	sdk.Logger(ctx).Info().Str("index", d.config.Index).Msg("index created")
+
+	return nil
+}
+
+// inferMapping returns the mapping definition of fields found in all samples.
+func inferMapping(samples []sdk.StructuredData) map[string]interface{} {
+	properties := make(map[string]interface{})
+
+	for _, sample := range samples {
+		inferProperties(properties, sample)
+	}
+
+	return map[string]interface{}{
+		"properties": properties,
+	}
+}
+
+// inferProperties merges mappings of all fields of the object into properties.
+func inferProperties(properties map[string]interface{}, object map[string]interface{}) {
+	for field, value := range object {
+		fieldMapping := inferFieldMapping(value)
+		if fieldMapping == nil {
+			continue
+		}
+
+		if existingMapping, ok := properties[field].(map[string]interface{}); ok {
+			fieldMapping = mergeFieldMappings(existingMapping, fieldMapping)
+		}
+
+		properties[field] = fieldMapping
+	}
+}
+
+// inferFieldMapping returns the mapping of a single value, or nil when the type could not be determined.
+func inferFieldMapping(value interface{}) map[string]interface{} {
+	switch typedValue := value.(type) {
+	case nil:
+		return nil
+
+	case bool:
+		return map[string]interface{}{"type": "boolean"}
+
+	case string:
+		return inferStringMapping(typedValue)
+
+	case time.Time:
+		return map[string]interface{}{"type": "date"}
+
+	case json.Number:
+		if _, err := typedValue.Int64(); err == nil {
+			return map[string]interface{}{"type": "long"}
+		}
+
+		return map[string]interface{}{"type": "double"}
+
+	case sdk.StructuredData:
+		return inferFieldMapping(map[string]interface{}(typedValue))
+
+	case map[string]interface{}:
+		properties := make(map[string]interface{})
+
+		inferProperties(properties, typedValue)
+
+		return map[string]interface{}{"properties": properties}
+	}
+
+	reflectValue := reflect.ValueOf(value)
+
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "long"}
+
+	case reflect.Float32, reflect.Float64:
+		// Numbers decoded from JSON are always floats, so whole numbers are most likely integers
+		if float := reflectValue.Float(); float == math.Trunc(float) && !math.IsInf(float, 0) {
+			return map[string]interface{}{"type": "long"}
+		}
+
+		return map[string]interface{}{"type": "double"}
+
+	case reflect.Slice, reflect.Array:
+		// Elasticsearch has no array type, arrays are mapped by the type of their elements
+		var elementsMapping map[string]interface{}
+
+		for i := 0; i < reflectValue.Len(); i++ {
+			elementMapping := inferFieldMapping(reflectValue.Index(i).Interface())
+
+			if elementsMapping == nil {
+				elementsMapping = elementMapping
+			} else if elementMapping != nil {
+				elementsMapping = mergeFieldMappings(elementsMapping, elementMapping)
+			}
+		}
+
+		// Arrays of objects are nested, so fields of each object are queried together
+		if _, isObject := elementsMapping["properties"]; isObject {
+			elementsMapping["type"] = "nested"
+		}
+
+		return elementsMapping
+
+	default:
+		return nil
+	}
+}
+
+func inferStringMapping(value string) map[string]interface{} {
+	for _, layout := range inferredDateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return map[string]interface{}{"type": "date"}
+		}
+	}
+
+	if len(value) > inferredKeywordMaxLength || strings.ContainsAny(value, "\n\r") {
+		return map[string]interface{}{"type": "text"}
+	}
+
+	return map[string]interface{}{"type": "keyword"}
+}
+
+// mergeFieldMappings returns the mapping compatible with values of both mappings.
+// Objects are merged field by field and stay nested when any of them is nested,
+// numbers are widened to `double` and other conflicts fall back to strings.
+func mergeFieldMappings(a, b map[string]interface{}) map[string]interface{} {
+	aProperties, aIsObject := a["properties"].(map[string]interface{})
+	bProperties, bIsObject := b["properties"].(map[string]interface{})
+
+	switch {
+	case aIsObject && bIsObject:
+		for field, fieldMapping := range bProperties {
+			if existingMapping, ok := aProperties[field].(map[string]interface{}); ok {
+				fieldMapping = mergeFieldMappings(existingMapping, fieldMapping.(map[string]interface{}))
+			}
+
+			aProperties[field] = fieldMapping
+		}
+
+		// A nested field accepts single objects as well
+		if b["type"] == "nested" {
+			a["type"] = "nested"
+		}
+
+		return a
+
+	case aIsObject:
+		return a
+
+	case bIsObject:
+		return b
+	}
+
+	aType, bType := a["type"], b["type"]
+
+	switch {
+	case aType == bType:
+		return a
+
+	case aType == "text" || bType == "text":
+		return map[string]interface{}{"type": "text"}
+
+	case (aType == "long" || aType == "double") && (bType == "long" || bType == "double"):
+		return map[string]interface{}{"type": "double"}
+
+	default:
+		return map[string]interface{}{"type": "keyword"}
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferMapping(t *testing.T) {
+	t.Run("Infers field types", func(t *testing.T) {
+		mapping := inferMapping([]sdk.StructuredData{
+			{
+				"id":          float64(1),
+				"price":       9.99,
+				"quantity":    int32(3),
+				"active":      true,
+				"createdAt":   "2022-06-01T12:00:00Z",
+				"birthDate":   "1990-01-31",
+				"updatedAt":   time.Now(),
+				"name":        "John",
+				"description": strings.Repeat("Lorem ipsum ", 30),
+				"tags":        []interface{}{"a", "b"},
+				"address": map[string]interface{}{
+					"city": "Warsaw",
+					"geo": map[string]interface{}{
+						"lat": 52.2297,
+					},
+				},
+				"deletedAt": nil,
+			},
+		})
+
+		require.Equal(t, map[string]interface{}{
+			"properties": map[string]interface{}{
+				"id":          map[string]interface{}{"type": "long"},
+				"price":       map[string]interface{}{"type": "double"},
+				"quantity":    map[string]interface{}{"type": "long"},
+				"active":      map[string]interface{}{"type": "boolean"},
+				"createdAt":   map[string]interface{}{"type": "date"},
+				"birthDate":   map[string]interface{}{"type": "date"},
+				"updatedAt":   map[string]interface{}{"type": "date"},
+				"name":        map[string]interface{}{"type": "keyword"},
+				"description": map[string]interface{}{"type": "text"},
+				"tags":        map[string]interface{}{"type": "keyword"},
+				"address": map[string]interface{}{
+					"properties": map[string]interface{}{
+						"city": map[string]interface{}{"type": "keyword"},
+						"geo": map[string]interface{}{
+							"properties": map[string]interface{}{
+								"lat": map[string]interface{}{"type": "double"},
+							},
+						},
+					},
+				},
+			},
+		}, mapping)
+	})
+
+	t.Run("Merges field types of all samples", func(t *testing.T) {
+		mapping := inferMapping([]sdk.StructuredData{
+			{
+				"amount":  float64(10),
+				"code":    "2022-06-01",
+				"comment": "short",
+				"address": map[string]interface{}{"city": "Warsaw"},
+			},
+			{
+				"amount":  10.5,
+				"code":    "ABC",
+				"comment": strings.Repeat("long ", 60),
+				"address": map[string]interface{}{"zip": "00-001"},
+				"note":    "added later",
+			},
+		})
+
+		require.Equal(t, map[string]interface{}{
+			"properties": map[string]interface{}{
+				"amount":  map[string]interface{}{"type": "double"},
+				"code":    map[string]interface{}{"type": "keyword"},
+				"comment": map[string]interface{}{"type": "text"},
+				"note":    map[string]interface{}{"type": "keyword"},
+				"address": map[string]interface{}{
+					"properties": map[string]interface{}{
+						"city": map[string]interface{}{"type": "keyword"},
+						"zip":  map[string]interface{}{"type": "keyword"},
+					},
+				},
+			},
+		}, mapping)
+	})
+
+	t.Run("Infers nested type for arrays of objects", func(t *testing.T) {
+		mapping := inferMapping([]sdk.StructuredData{
+			{
+				"orders": []interface{}{
+					map[string]interface{}{"id": float64(1), "items": []interface{}{
+						map[string]interface{}{"sku": "A-1"},
+					}},
+					map[string]interface{}{"id": float64(2), "note": "gift"},
+				},
+			},
+		})
+
+		require.Equal(t, map[string]interface{}{
+			"properties": map[string]interface{}{
+				"orders": map[string]interface{}{
+					"type": "nested",
+					"properties": map[string]interface{}{
+						"id":   map[string]interface{}{"type": "long"},
+						"note": map[string]interface{}{"type": "keyword"},
+						"items": map[string]interface{}{
+							"type": "nested",
+							"properties": map[string]interface{}{
+								"sku": map[string]interface{}{"type": "keyword"},
+							},
+						},
+					},
+				},
+			},
+		}, mapping)
+	})
+
+	t.Run("Keeps nested type when other samples have a single object", func(t *testing.T) {
+		mapping := inferMapping([]sdk.StructuredData{
+			{"address": map[string]interface{}{"city": "Warsaw"}},
+			{"address": []interface{}{map[string]interface{}{"zip": "00-001"}}},
+		})
+
+		require.Equal(t, map[string]interface{}{
+			"properties": map[string]interface{}{
+				"address": map[string]interface{}{
+					"type": "nested",
+					"properties": map[string]interface{}{
+						"city": map[string]interface{}{"type": "keyword"},
+						"zip":  map[string]interface{}{"type": "keyword"},
+					},
+				},
+			},
+		}, mapping)
+	})
+}
+
+func TestDestination_WriteAsync_MappingInference(t *testing.T) {
+	t.Run("Creates index with inferred mapping before the first flush", func(t *testing.T) {
+		var requests []string
+
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return map[string]interface{}{"create": map[string]interface{}{}}, item.Payload, nil
+			},
+
+			IndexExistsFunc: func(ctx context.Context) (bool, error) {
+				return false, nil
+			},
+
+			CreateIndexFunc: func(ctx context.Context, body io.Reader) error {
+				requests = append(requests, "create index")
+
+				contents, err := io.ReadAll(body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{
+					"mappings": {
+						"user": {
+							"properties": {
+								"name": {"type": "keyword"},
+								"age": {"type": "long"}
+							}
+						}
+					}
+				}`, string(contents))
+
+				return nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				requests = append(requests, "bulk")
+
+				data, err := json.Marshal(bulkResponse{
+					Items: []bulkResponseItems{
+						{Create: &bulkResponseItem{Status: http.StatusCreated}},
+						{Create: &bulkResponseItem{Status: http.StatusCreated}},
+						{Create: &bulkResponseItem{Status: http.StatusCreated}},
+					},
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Version:                 elasticsearch.Version6,
+				Type:                    "user",
+				BulkSize:                3,
+				MappingInferenceSamples: 2,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		// The third Record is not sampled, so its field is left to dynamic mapping
+		for _, payload := range []sdk.StructuredData{
+			{"name": "John"},
+			{"name": "Jane", "age": 30},
+			{"name": "Jack", "age": 40, "active": true},
+		} {
+			require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: payload}, successfulAckFunc(t)))
+		}
+
+		require.NoError(t, destination.Flush(context.Background()))
+
+		require.Equal(t, []string{"create index", "bulk"}, requests)
+	})
+
+	t.Run("Ends sampling on the first flush before the configured number of samples", func(t *testing.T) {
+		var requests []string
+
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return map[string]interface{}{"create": map[string]interface{}{}}, item.Payload, nil
+			},
+
+			IndexExistsFunc: func(ctx context.Context) (bool, error) {
+				return false, nil
+			},
+
+			CreateIndexFunc: func(ctx context.Context, body io.Reader) error {
+				requests = append(requests, "create index")
+
+				contents, err := io.ReadAll(body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{
+					"mappings": {
+						"properties": {
+							"name": {"type": "keyword"},
+							"age": {"type": "long"}
+						}
+					}
+				}`, string(contents))
+
+				return nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				requests = append(requests, "bulk")
+
+				data, err := json.Marshal(bulkResponse{
+					Items: []bulkResponseItems{
+						{Create: &bulkResponseItem{Status: http.StatusCreated}},
+						{Create: &bulkResponseItem{Status: http.StatusCreated}},
+					},
+				})
+				require.NoError(t, err)
+
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Version:                 elasticsearch.Version8,
+				BulkSize:                2,
+				MappingInferenceSamples: 10,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+		}
+
+		// Records after the first flush are neither sampled nor change the created index
+		for _, payload := range []sdk.StructuredData{
+			{"name": "John"},
+			{"name": "Jane", "age": 30},
+			{"name": "Jack", "active": true},
+			{"name": "Jill", "active": false},
+		} {
+			require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: payload}, successfulAckFunc(t)))
+		}
+
+		require.Equal(t, []string{"create index", "bulk", "bulk"}, requests)
+		require.Empty(t, destination.mappingSamples)
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "conduit_elasticsearch"
+
+// metrics collects the statistics of the destination in its own Prometheus registry.
+// All methods are no-op on nil metrics, so the destination works without them.
+type metrics struct {
+	registry *prometheus.Registry
+
+	bulkRequests        *prometheus.CounterVec
+	bulkRequestDuration prometheus.Histogram
+	bytesSent           prometheus.Counter
+	documents           *prometheus.CounterVec
+	itemFailures        *prometheus.CounterVec
+	retries             *prometheus.CounterVec
+	bufferDepth         prometheus.Gauge
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+
+		bulkRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "bulk_requests_total",
+			Help:      "The number of sent Bulk API requests by their result.",
+		}, []string{"result"}),
+
+		bulkRequestDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "bulk_request_duration_seconds",
+			Help:      "The latency of Bulk API requests.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+
+		bytesSent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "bulk_request_bytes_total",
+			Help:      "The number of bytes sent in Bulk API request bodies, before compression.",
+		}),
+
+		documents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "documents_total",
+			Help:      "The number of successfully written Documents by the action type.",
+		}, []string{"action"}),
+
+		itemFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "item_failures_total",
+			Help:      "The number of failed Bulk API items by the response status and error type.",
+		}, []string{"status", "error_type"}),
+
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "retries_total",
+			Help:      "The number of retries of whole Bulk API requests and of individual items.",
+		}, []string{"scope"}),
+
+		bufferDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "buffer_operations",
+			Help:      "The number of operations waiting in the buffer for the next bulk request.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.bulkRequests,
+		m.bulkRequestDuration,
+		m.bytesSent,
+		m.documents,
+		m.itemFailures,
+		m.retries,
+		m.bufferDepth,
+	)
+
+	return m
+}
+
+// observeBulkRequest records a single Bulk API request of given size.
+func (m *metrics) observeBulkRequest(size int, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+
+	m.bulkRequests.WithLabelValues(result).Inc()
+	m.bulkRequestDuration.Observe(duration.Seconds())
+	m.bytesSent.Add(float64(size))
+}
+
+// observeItem records the result of a single Bulk API item.
+func (m *metrics) observeItem(action string, item bulkResponseItem) {
+	if m == nil {
+		return
+	}
+
+	if item.Status >= 200 && item.Status < 300 {
+		m.documents.WithLabelValues(action).Inc()
+
+		return
+	}
+
+	var errorType string
+	if item.Error != nil {
+		errorType = item.Error.Type
+	}
+
+	m.itemFailures.WithLabelValues(strconv.Itoa(item.Status), errorType).Inc()
+}
+
+// observeRetries records retries of whole Bulk API requests or of individual items.
+func (m *metrics) observeRetries(scope string, count int) {
+	if m == nil {
+		return
+	}
+
+	m.retries.WithLabelValues(scope).Add(float64(count))
+}
+
+// setBufferDepth records the number of buffered operations.
+func (m *metrics) setBufferDepth(depth int) {
+	if m == nil {
+		return
+	}
+
+	m.bufferDepth.Set(float64(depth))
+}
+
+// serveMetrics starts serving metrics of the registry in Prometheus text format on given address, at the /metrics path.
+// The address is bound before returning, so failures to listen are reported to the caller.
+func serveMetrics(ctx context.Context, address string, registry *prometheus.Registry) (*http.Server, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{
+		Addr:              listener.Addr().String(),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			sdk.Logger(ctx).Error().Err(err).Msg("metrics listener failure")
+		}
+	}()
+
+	return server, nil
+}
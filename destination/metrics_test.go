@@ -0,0 +1,103 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Run("Records bulk requests", func(t *testing.T) {
+		m := newMetrics()
+
+		m.observeBulkRequest(100, 50*time.Millisecond, nil)
+		m.observeBulkRequest(20, time.Second, errors.New("connection refused"))
+
+		require.Equal(t, float64(1), testutil.ToFloat64(m.bulkRequests.WithLabelValues("success")))
+		require.Equal(t, float64(1), testutil.ToFloat64(m.bulkRequests.WithLabelValues("failure")))
+		require.Equal(t, float64(120), testutil.ToFloat64(m.bytesSent))
+		require.Equal(t, 1, testutil.CollectAndCount(m.bulkRequestDuration))
+	})
+
+	t.Run("Records items by action and failure", func(t *testing.T) {
+		m := newMetrics()
+
+		m.observeItem("create", bulkResponseItem{Status: http.StatusCreated})
+		m.observeItem("create", bulkResponseItem{Status: http.StatusCreated})
+		m.observeItem("delete", bulkResponseItem{Status: http.StatusOK})
+		m.observeItem("index", bulkResponseItem{
+			Status: http.StatusBadRequest,
+			Error: &bulkResponseItemError{
+				Type: "mapper_parsing_exception",
+			},
+		})
+
+		require.Equal(t, float64(2), testutil.ToFloat64(m.documents.WithLabelValues("create")))
+		require.Equal(t, float64(1), testutil.ToFloat64(m.documents.WithLabelValues("delete")))
+		require.Equal(t, float64(1), testutil.ToFloat64(m.itemFailures.WithLabelValues("400", "mapper_parsing_exception")))
+	})
+
+	t.Run("Ignores observations without metrics", func(t *testing.T) {
+		var m *metrics
+
+		m.observeBulkRequest(100, time.Second, nil)
+		m.observeItem("create", bulkResponseItem{Status: http.StatusCreated})
+		m.observeRetries("item", 1)
+		m.setBufferDepth(1)
+	})
+}
+
+func TestServeMetrics(t *testing.T) {
+	t.Run("Serves metrics of the registry", func(t *testing.T) {
+		m := newMetrics()
+		m.observeRetries("request", 2)
+		m.setBufferDepth(5)
+
+		server, err := serveMetrics(context.Background(), "127.0.0.1:0", m.registry)
+		require.NoError(t, err)
+
+		defer server.Close()
+
+		response, err := http.Get("http://" + server.Addr + "/metrics")
+		require.NoError(t, err)
+
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Contains(t, string(body), `conduit_elasticsearch_retries_total{scope="request"} 2`)
+		require.Contains(t, string(body), "conduit_elasticsearch_buffer_operations 5")
+	})
+
+	t.Run("Fails when address is in use", func(t *testing.T) {
+		server, err := serveMetrics(context.Background(), "127.0.0.1:0", newMetrics().registry)
+		require.NoError(t, err)
+
+		defer server.Close()
+
+		_, err = serveMetrics(context.Background(), server.Addr, newMetrics().registry)
+		require.ErrorContains(t, err, "address already in use")
+	})
+}
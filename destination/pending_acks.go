@@ -0,0 +1,94 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"sort"
+	"sync"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// pendingAcks keeps track of Records which were queued but not acked yet,
+// so they can be nacked when they could not be flushed before Teardown.
+type pendingAcks struct {
+	mutex sync.Mutex
+	next  uint64
+	acks  map[uint64]sdk.AckFunc
+}
+
+// Track registers the ack function of a Record and returns the function which must be used to ack it instead.
+// The returned function does nothing once the Record was nacked by NackAll.
+func (p *pendingAcks) Track(ackFunc sdk.AckFunc) sdk.AckFunc {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.acks == nil {
+		p.acks = make(map[uint64]sdk.AckFunc)
+	}
+
+	id := p.next
+	p.next++
+	p.acks[id] = ackFunc
+
+	return func(err error) error {
+		p.mutex.Lock()
+		ackFunc, ok := p.acks[id]
+		delete(p.acks, id)
+		p.mutex.Unlock()
+
+		if !ok {
+			return nil
+		}
+
+		return ackFunc(err)
+	}
+}
+
+// NackAll acks all pending Records with given error, in the order they were tracked.
+func (p *pendingAcks) NackAll(err error) error {
+	p.mutex.Lock()
+	ids := make([]uint64, 0, len(p.acks))
+	for id := range p.acks {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	ackFuncs := make([]sdk.AckFunc, 0, len(ids))
+	for _, id := range ids {
+		ackFuncs = append(ackFuncs, p.acks[id])
+		delete(p.acks, id)
+	}
+	p.mutex.Unlock()
+
+	for _, ackFunc := range ackFuncs {
+		if ackErr := ackFunc(err); ackErr != nil {
+			return ackErr
+		}
+	}
+
+	return nil
+}
+
+// Len returns the number of pending Records.
+func (p *pendingAcks) Len() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return len(p.acks)
+}
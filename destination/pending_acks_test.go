@@ -0,0 +1,68 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingAcks_NackAll(t *testing.T) {
+	t.Run("Nacks pending Records in order", func(t *testing.T) {
+		var (
+			pending pendingAcks
+			nacked  []int
+		)
+
+		nackErr := errors.New("teardown")
+
+		ackFuncs := make([]func(error) error, 0, 3)
+		for n := 0; n < 3; n++ {
+			n := n
+
+			ackFuncs = append(ackFuncs, pending.Track(func(err error) error {
+				require.Equal(t, nackErr, err)
+
+				nacked = append(nacked, n)
+
+				return nil
+			}))
+		}
+
+		require.NoError(t, ackFuncs[1](nackErr))
+		require.Equal(t, 2, pending.Len())
+
+		require.NoError(t, pending.NackAll(nackErr))
+		require.Equal(t, []int{1, 0, 2}, nacked)
+		require.Equal(t, 0, pending.Len())
+	})
+
+	t.Run("Ignores acks of nacked Records", func(t *testing.T) {
+		var pending pendingAcks
+
+		calls := 0
+		ackFunc := pending.Track(func(err error) error {
+			calls++
+
+			return nil
+		})
+
+		require.NoError(t, pending.NackAll(errors.New("teardown")))
+		require.NoError(t, ackFunc(nil))
+		require.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,332 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch"
+)
+
+const (
+	// templateMetaVersion is the key of template's `_meta` holding the configured template version
+	templateMetaVersion = "version"
+
+	// templateMetaChecksum is the key of template's `_meta` holding the checksum of the configured template body
+	templateMetaChecksum = "checksum"
+)
+
+type (
+	templateGetFunc func(ctx context.Context, name string) (map[string]interface{}, error)
+	templatePutFunc func(ctx context.Context, name string, body io.Reader) error
+)
+
+// ensureTemplates installs configured component templates and the index template,
+// unless the cluster already has the same or a newer version of them.
+// The index template, installed or left unchanged, must match the configured index.
+func (d *Destination) ensureTemplates(ctx context.Context) error {
+	// Composable index templates and component templates were introduced in Elasticsearch 7.8
+	if d.config.Version == elasticsearch.Version7 && d.serverInfo.Before(7, 8) {
+		return fmt.Errorf("index and component templates are supported only for versions 7.8 and newer: %s", d.serverInfo)
+	}
+
+	// Component templates go first, as the index template may be composed of them
+	names := make([]string, 0, len(d.config.ComponentTemplates))
+	for name := range d.config.ComponentTemplates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := d.ensureTemplate(
+			ctx,
+			"component template",
+			name,
+			d.config.ComponentTemplates[name],
+			d.client.GetComponentTemplate,
+			d.client.PutComponentTemplate,
+			nil,
+		); err != nil {
+			return err
+		}
+	}
+
+	if len(d.config.IndexTemplate) > 0 {
+		return d.ensureTemplate(
+			ctx,
+			"index template",
+			d.config.IndexTemplateName,
+			d.config.IndexTemplate,
+			d.client.GetIndexTemplate,
+			d.client.PutIndexTemplate,
+			d.checkTemplatePatterns,
+		)
+	}
+
+	return nil
+}
+
+// ensureTemplate installs the template unless the same or a newer version is installed.
+// The template which remains installed is checked with validate, when given.
+func (d *Destination) ensureTemplate(
+	ctx context.Context,
+	kind, name string,
+	body []byte,
+	get templateGetFunc,
+	put templatePutFunc,
+	validate func(template map[string]interface{}) error,
+) error {
+	template := make(map[string]interface{})
+	if err := json.Unmarshal(body, &template); err != nil {
+		return fmt.Errorf("failed to parse %s %q: %w", kind, name, err)
+	}
+
+	checksum, err := d.templateChecksum(template)
+	if err != nil {
+		return fmt.Errorf("failed to prepare %s %q: %w", kind, name, err)
+	}
+
+	existingTemplate, err := get(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %q: %w", kind, name, err)
+	}
+
+	if existingTemplate != nil {
+		existingVersion, existingChecksum, err := d.templateVersion(existingTemplate)
+		if err != nil {
+			return fmt.Errorf("failed to read installed %s %q: %w", kind, name, err)
+		}
+
+		if existingVersion >= d.config.TemplateVersion {
+			switch {
+			case existingVersion > d.config.TemplateVersion:
+				sdk.Logger(ctx).Warn().
+					Str("name", name).
+					Uint64("version", existingVersion).
+					Msgf("newer version of %s is installed, leaving it unchanged", kind)
+
+			case existingChecksum != checksum:
+				sdk.Logger(ctx).Warn().
+					Str("name", name).
+					Uint64("version", existingVersion).
+					Msgf("installed %s differs from the configured one, increase %q to replace it", kind, ConfigKeyTemplateVersion)
+			}
+
+			if validate != nil {
+				if err := validate(existingTemplate); err != nil {
+					return fmt.Errorf("installed %s %q is invalid: %w", kind, name, err)
+				}
+			}
+
+			return nil
+		}
+	}
+
+	if validate != nil {
+		if err := validate(template); err != nil {
+			return fmt.Errorf("%s %q is invalid: %w", kind, name, err)
+		}
+	}
+
+	d.setTemplateVersion(template, checksum)
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed to prepare %s %q: %w", kind, name, err)
+	}
+
+	if err := put(ctx, name, bytes.NewReader(encodedTemplate)); err != nil {
+		return fmt.Errorf("failed to put %s %q: %w", kind, name, err)
+	}
+
+	sdk.Logger(ctx).Info().
+		Str("name", name).
+		Uint64("version", d.config.TemplateVersion).
+		Msgf("%s installed", kind)
+
+	return nil
+}
+
+// checkTemplatePatterns verifies that the index template applies to the index Documents are written to.
+// With index lifecycle management, Documents are written to indices of the rollover series.
+func (d *Destination) checkTemplatePatterns(template map[string]interface{}) error {
+	index := d.config.Index
+	if d.config.ILMPolicy != "" {
+		index += "-000001"
+	}
+
+	patterns := templatePatterns(template)
+
+	for _, pattern := range patterns {
+		if wildcardMatch(pattern, index) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("index patterns %q do not match index %q", patterns, index)
+}
+
+// templateVersion returns the version and checksum of the installed template.
+// Legacy templates have no `_meta`, so the checksum is calculated from their contents.
+func (d *Destination) templateVersion(template map[string]interface{}) (uint64, string, error) {
+	if d.config.legacyTemplates() {
+		version, _ := template["version"].(float64)
+
+		checksum, err := d.templateChecksum(template)
+		if err != nil {
+			return 0, "", err
+		}
+
+		return uint64(version), checksum, nil
+	}
+
+	meta, _ := template["_meta"].(map[string]interface{})
+	version, _ := meta[templateMetaVersion].(float64)
+	checksum, _ := meta[templateMetaChecksum].(string)
+
+	return uint64(version), checksum, nil
+}
+
+// templateChecksum returns the checksum of the template body.
+// Legacy templates are normalized first, so the configured body compares equal to the one returned by the cluster.
+func (d *Destination) templateChecksum(template map[string]interface{}) (string, error) {
+	if d.config.legacyTemplates() {
+		return templateChecksum(normalizeLegacyTemplate(template))
+	}
+
+	return templateChecksum(template)
+}
+
+// setTemplateVersion marks the template with the configured version and the checksum of its body.
+func (d *Destination) setTemplateVersion(template map[string]interface{}, checksum string) {
+	if d.config.legacyTemplates() {
+		template["version"] = d.config.TemplateVersion
+
+		return
+	}
+
+	meta, ok := template["_meta"].(map[string]interface{})
+	if !ok {
+		meta = make(map[string]interface{})
+		template["_meta"] = meta
+	}
+
+	meta[templateMetaVersion] = d.config.TemplateVersion
+	meta[templateMetaChecksum] = checksum
+}
+
+// templateChecksum returns the checksum of the template body, independent of its formatting and keys order.
+func templateChecksum(template map[string]interface{}) (string, error) {
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+
+	checksum := sha256.Sum256(encodedTemplate)
+
+	return hex.EncodeToString(checksum[:]), nil
+}
+
+// normalizeLegacyTemplate returns the contents of the legacy template in the form the cluster returns them:
+// patterns as a list, settings as flat `index.` prefixed strings, and no version.
+func normalizeLegacyTemplate(template map[string]interface{}) map[string]interface{} {
+	order, _ := template["order"].(float64)
+
+	settings := make(map[string]interface{})
+	if templateSettings, ok := template["settings"].(map[string]interface{}); ok {
+		flattenSettings("", templateSettings, settings)
+	}
+
+	normalized := map[string]interface{}{
+		"index_patterns": templatePatterns(template),
+		"order":          order,
+		"settings":       settings,
+		"mappings":       map[string]interface{}{},
+		"aliases":        map[string]interface{}{},
+	}
+
+	for _, key := range []string{"mappings", "aliases"} {
+		if value, ok := template[key].(map[string]interface{}); ok {
+			normalized[key] = value
+		}
+	}
+
+	return normalized
+}
+
+// flattenSettings adds settings to flat with dotted keys prefixed by `index.` and values converted to strings.
+func flattenSettings(prefix string, settings, flat map[string]interface{}) {
+	for key, value := range settings {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenSettings(key, nested, flat)
+
+			continue
+		}
+
+		if !strings.HasPrefix(key, "index.") {
+			key = "index." + key
+		}
+
+		flat[key] = fmt.Sprint(value)
+	}
+}
+
+// templatePatterns returns index patterns of the template.
+// Elasticsearch 5 defines a single pattern in the `template` field of legacy templates.
+func templatePatterns(template map[string]interface{}) []string {
+	var patterns []string
+
+	switch value := template["index_patterns"].(type) {
+	case string:
+		patterns = append(patterns, value)
+
+	case []interface{}:
+		for _, pattern := range value {
+			if pattern, ok := pattern.(string); ok {
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+
+	if pattern, ok := template["template"].(string); ok {
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns
+}
+
+// wildcardMatch reports whether name matches the index pattern, in which `*` matches any characters.
+func wildcardMatch(pattern, name string) bool {
+	expression := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+
+	matched, _ := regexp.MatchString(expression, name)
+
+	return matched
+}
@@ -0,0 +1,357 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestination_EnsureTemplates(t *testing.T) {
+	const indexTemplate = `{"index_patterns":["users-*"],"composed_of":["users-mappings"],"priority":100}`
+
+	componentTemplates := map[string]json.RawMessage{
+		"users-settings": json.RawMessage(`{"template":{"settings":{"number_of_shards":1}}}`),
+		"users-mappings": json.RawMessage(`{"template":{"mappings":{"properties":{"name":{"type":"keyword"}}}}}`),
+	}
+
+	checksum := func(body string) string {
+		template := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal([]byte(body), &template))
+
+		sum, err := templateChecksum(template)
+		require.NoError(t, err)
+
+		return sum
+	}
+
+	t.Run("Installs missing templates, component templates first", func(t *testing.T) {
+		var installed []string
+
+		esClientMock := clientMock{
+			GetComponentTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+				return nil, nil
+			},
+
+			PutComponentTemplateFunc: func(ctx context.Context, name string, body io.Reader) error {
+				installed = append(installed, name)
+
+				return nil
+			},
+
+			GetIndexTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+				return nil, nil
+			},
+
+			PutIndexTemplateFunc: func(ctx context.Context, name string, body io.Reader) error {
+				installed = append(installed, name)
+
+				contents, err := io.ReadAll(body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{
+					"index_patterns": ["users-*"],
+					"composed_of": ["users-mappings"],
+					"priority": 100,
+					"_meta": {"version": 3, "checksum": "`+checksum(indexTemplate)+`"}
+				}`, string(contents))
+
+				return nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Version:            elasticsearch.Version8,
+				Index:              "users-2022",
+				IndexTemplate:      []byte(indexTemplate),
+				IndexTemplateName:  "users",
+				ComponentTemplates: componentTemplates,
+				TemplateVersion:    3,
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureTemplates(context.Background()))
+		require.Equal(t, []string{"users-mappings", "users-settings", "users"}, installed)
+	})
+
+	t.Run("Replaces template with older version", func(t *testing.T) {
+		esClientMock := clientMock{
+			GetIndexTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+				return map[string]interface{}{
+					"index_patterns": []interface{}{"logs-*"},
+					"_meta":          map[string]interface{}{"version": float64(1), "checksum": "outdated"},
+				}, nil
+			},
+
+			PutIndexTemplateFunc: func(ctx context.Context, name string, body io.Reader) error {
+				return nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Version:           elasticsearch.Version7,
+				Index:             "users-2022",
+				IndexTemplate:     []byte(indexTemplate),
+				IndexTemplateName: "users",
+				TemplateVersion:   2,
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureTemplates(context.Background()))
+		require.Len(t, esClientMock.PutIndexTemplateCalls(), 1)
+	})
+
+	for _, tt := range []struct {
+		name string
+		meta map[string]interface{}
+	}{
+		{
+			name: "Leaves template with newer version unchanged",
+			meta: map[string]interface{}{"version": float64(3), "checksum": "installed-by-hand"},
+		},
+		{
+			name: "Leaves template with the same version but different body unchanged",
+			meta: map[string]interface{}{"version": float64(2), "checksum": "installed-by-hand"},
+		},
+		{
+			name: "Leaves up to date template unchanged",
+			meta: map[string]interface{}{"version": float64(2), "checksum": checksum(indexTemplate)},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			esClientMock := clientMock{
+				GetIndexTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+					return map[string]interface{}{"index_patterns": []interface{}{"users-*"}, "_meta": tt.meta}, nil
+				},
+			}
+
+			destination := Destination{
+				config: Config{
+					Version:           elasticsearch.Version8,
+					Index:             "users-2022",
+					IndexTemplate:     []byte(indexTemplate),
+					IndexTemplateName: "users",
+					TemplateVersion:   2,
+				},
+				client: &esClientMock,
+			}
+
+			require.NoError(t, destination.ensureTemplates(context.Background()))
+			require.Len(t, esClientMock.PutIndexTemplateCalls(), 0)
+		})
+	}
+
+	t.Run("Uses native version of legacy templates", func(t *testing.T) {
+		esClientMock := clientMock{
+			GetIndexTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+				return map[string]interface{}{"version": float64(1)}, nil
+			},
+
+			PutIndexTemplateFunc: func(ctx context.Context, name string, body io.Reader) error {
+				contents, err := io.ReadAll(body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"index_patterns":["users-*"],"order":1,"version":2}`, string(contents))
+
+				return nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Version:           elasticsearch.Version6,
+				Index:             "users-2022",
+				IndexTemplate:     []byte(`{"index_patterns":["users-*"],"order":1}`),
+				IndexTemplateName: "users",
+				TemplateVersion:   2,
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureTemplates(context.Background()))
+		require.Len(t, esClientMock.PutIndexTemplateCalls(), 1)
+	})
+	t.Run("Fails when installed template does not match the index", func(t *testing.T) {
+		esClientMock := clientMock{
+			GetIndexTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+				return map[string]interface{}{
+					"index_patterns": []interface{}{"logs-*"},
+					"_meta":          map[string]interface{}{"version": float64(3)},
+				}, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Version:           elasticsearch.Version8,
+				Index:             "users-2022",
+				IndexTemplate:     []byte(indexTemplate),
+				IndexTemplateName: "users",
+				TemplateVersion:   2,
+			},
+			client: &esClientMock,
+		}
+
+		require.EqualError(
+			t,
+			destination.ensureTemplates(context.Background()),
+			`installed index template "users" is invalid: index patterns ["logs-*"] do not match index "users-2022"`,
+		)
+	})
+
+	t.Run("Fails before installing template which does not match the index", func(t *testing.T) {
+		esClientMock := clientMock{
+			GetIndexTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+				return nil, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Version:           elasticsearch.Version8,
+				Index:             "accounts",
+				IndexTemplate:     []byte(indexTemplate),
+				IndexTemplateName: "users",
+			},
+			client: &esClientMock,
+		}
+
+		require.EqualError(
+			t,
+			destination.ensureTemplates(context.Background()),
+			`index template "users" is invalid: index patterns ["users-*"] do not match index "accounts"`,
+		)
+		require.Len(t, esClientMock.PutIndexTemplateCalls(), 0)
+	})
+
+	t.Run("Matches the first index of the rollover series", func(t *testing.T) {
+		esClientMock := clientMock{
+			GetIndexTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+				return nil, nil
+			},
+
+			PutIndexTemplateFunc: func(ctx context.Context, name string, body io.Reader) error {
+				return nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Version:           elasticsearch.Version8,
+				Index:             "users",
+				IndexTemplate:     []byte(indexTemplate),
+				IndexTemplateName: "users",
+				ILMPolicy:         "users-policy",
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureTemplates(context.Background()))
+		require.Len(t, esClientMock.PutIndexTemplateCalls(), 1)
+	})
+
+	t.Run("Fails for Elasticsearch older than 7.8", func(t *testing.T) {
+		destination := Destination{
+			config: Config{
+				Version:           elasticsearch.Version7,
+				Index:             "users-2022",
+				IndexTemplate:     []byte(indexTemplate),
+				IndexTemplateName: "users",
+			},
+			client: &clientMock{},
+		}
+		destination.serverInfo.Version.Number = "7.7.1"
+
+		require.EqualError(
+			t,
+			destination.ensureTemplates(context.Background()),
+			"index and component templates are supported only for versions 7.8 and newer: Elasticsearch 7.7.1",
+		)
+	})
+
+	t.Run("Leaves legacy template with the same version unchanged", func(t *testing.T) {
+		esClientMock := clientMock{
+			GetIndexTemplateFunc: func(ctx context.Context, name string) (map[string]interface{}, error) {
+				return map[string]interface{}{"template": "users-*", "version": float64(2)}, nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				Version:           elasticsearch.Version5,
+				Index:             "users-2022",
+				IndexTemplate:     []byte(`{"template":"users-*","order":1}`),
+				IndexTemplateName: "users",
+				TemplateVersion:   2,
+			},
+			client: &esClientMock,
+		}
+
+		require.NoError(t, destination.ensureTemplates(context.Background()))
+		require.Len(t, esClientMock.PutIndexTemplateCalls(), 0)
+	})
+}
+
+func TestDestination_TemplateChecksum(t *testing.T) {
+	const configured = `{"index_patterns":["users-*"],"settings":{"number_of_shards":1},"mappings":{"_doc":{"dynamic":false}}}`
+
+	for _, tt := range []struct {
+		name      string
+		installed string
+		drift     bool
+	}{
+		{
+			name:      "Legacy template returned by the cluster matches the configured one",
+			installed: `{"order":0,"version":2,"index_patterns":["users-*"],"settings":{"index":{"number_of_shards":"1"}},"mappings":{"_doc":{"dynamic":false}},"aliases":{}}`,
+		},
+		{
+			name:      "Legacy template with different settings drifts from the configured one",
+			installed: `{"order":0,"version":2,"index_patterns":["users-*"],"settings":{"index":{"number_of_shards":"2"}},"mappings":{"_doc":{"dynamic":false}},"aliases":{}}`,
+			drift:     true,
+		},
+		{
+			name:      "Legacy template with different patterns drifts from the configured one",
+			installed: `{"order":0,"version":2,"index_patterns":["accounts-*"],"settings":{"index":{"number_of_shards":"1"}},"mappings":{"_doc":{"dynamic":false}},"aliases":{}}`,
+			drift:     true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			destination := Destination{
+				config: Config{
+					Version: elasticsearch.Version6,
+				},
+			}
+
+			checksum := func(body string) string {
+				template := make(map[string]interface{})
+				require.NoError(t, json.Unmarshal([]byte(body), &template))
+
+				sum, err := destination.templateChecksum(template)
+				require.NoError(t, err)
+
+				return sum
+			}
+
+			require.Equal(t, tt.drift, checksum(configured) != checksum(tt.installed))
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDestination_Tracing(t *testing.T) {
+	t.Run("Records spans of flush stages", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+
+		var opaqueID string
+
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return string(item.Payload.Bytes()), string(item.Payload.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				opaqueID = internal.OpaqueID(ctx)
+
+				return io.NopCloser(strings.NewReader(`{"items":[{"create":{"status":201}},{"create":{"status":429}}]}`)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:        10,
+				FailurePolicies: defaultFailurePolicies,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+			tracerProvider:  sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("1111")}, successfulAckFunc(t)))
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("2222")}, unsuccessfulAckFunc(t, "item with key= create failure: unknown error")))
+		require.NoError(t, destination.Flush(context.Background()))
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 5)
+
+		prepare, execute, round, flushQueue, flush := spans[0], spans[1], spans[2], spans[3], spans[4]
+
+		require.Equal(t, "prepareBulkRequestPayload", prepare.Name())
+		require.Contains(t, prepare.Attributes(), attribute.Int("records", 2))
+		require.Contains(t, prepare.Attributes(), attribute.Int("bytes", 28))
+		require.Equal(t, round.SpanContext().SpanID(), prepare.Parent().SpanID())
+
+		require.Equal(t, "executeBulkRequest", execute.Name())
+		require.Contains(t, execute.Attributes(), attribute.Int("items", 2))
+		require.Contains(t, execute.Attributes(), attribute.Int("failures", 1))
+		require.Equal(t, round.SpanContext().SpanID(), execute.Parent().SpanID())
+
+		require.Equal(t, "executeRound", round.Name())
+		require.Contains(t, round.Attributes(), attribute.Int("attempt", 0))
+		require.Contains(t, round.Attributes(), attribute.Int("records", 2))
+		require.Contains(t, round.Attributes(), attribute.Int("retries", 1))
+		require.Equal(t, flushQueue.SpanContext().SpanID(), round.Parent().SpanID())
+
+		require.Equal(t, "flushOperationsQueue", flushQueue.Name())
+		require.Contains(t, flushQueue.Attributes(), attribute.Int("records", 2))
+		require.Equal(t, flush.SpanContext().SpanID(), flushQueue.Parent().SpanID())
+
+		require.Equal(t, "Flush", flush.Name())
+		require.Contains(t, flush.Attributes(), attribute.Int("records", 2))
+		require.Equal(t, codes.Unset, flush.Status().Code)
+
+		require.Equal(t, flush.SpanContext().TraceID().String(), opaqueID)
+	})
+
+	t.Run("Parents spans of bulk workers to the threshold flush", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return string(item.Payload.Bytes()), string(item.Payload.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(`{"items":[{"create":{"status":201}},{"create":{"status":201}}]}`)), nil
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize:        2,
+				FailurePolicies: defaultFailurePolicies,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+			tracerProvider:  sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		}
+		destination.workers = newBulkWorkers(context.Background(), 1, destination.executeOperations)
+
+		defer destination.workers.Close()
+
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("1111")}, successfulAckFunc(t)))
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("2222")}, successfulAckFunc(t)))
+		require.NoError(t, destination.workers.Wait())
+
+		spansByName := make(map[string]sdktrace.ReadOnlySpan)
+		for _, span := range recorder.Ended() {
+			spansByName[span.Name()] = span
+		}
+
+		flushQueue, round := spansByName["flushOperationsQueue"], spansByName["executeRound"]
+
+		require.NotNil(t, flushQueue)
+		require.Contains(t, flushQueue.Attributes(), attribute.Int("records", 2))
+
+		require.NotNil(t, round)
+		require.Equal(t, flushQueue.SpanContext().TraceID(), round.SpanContext().TraceID())
+		require.Equal(t, flushQueue.SpanContext().SpanID(), round.Parent().SpanID())
+	})
+
+	t.Run("Marks span as failed when bulk request fails", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+
+		esClientMock := clientMock{
+			PrepareCreateOperationFunc: func(item sdk.Record) (interface{}, interface{}, error) {
+				return string(item.Payload.Bytes()), string(item.Payload.Bytes()), nil
+			},
+
+			BulkFunc: func(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+				return nil, &internal.ResponseError{StatusCode: 400, Reason: "bad request"}
+			},
+		}
+
+		destination := Destination{
+			config: Config{
+				BulkSize: 10,
+			},
+			client:          &esClientMock,
+			operationsQueue: make(BufferQueue, 0),
+			tracerProvider:  sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		}
+
+		require.NoError(t, destination.WriteAsync(context.Background(), sdk.Record{Payload: sdk.RawData("1111")}, successfulAckFunc(t)))
+		require.EqualError(t, destination.Flush(context.Background()), "bulk request failure: bad request")
+
+		for _, span := range recorder.Ended() {
+			if span.Name() == "prepareBulkRequestPayload" {
+				require.Equal(t, codes.Unset, span.Status().Code)
+
+				continue
+			}
+
+			require.Equal(t, codes.Error, span.Status().Code, span.Name())
+			require.Equal(t, "bulk request failure: bad request", span.Status().Description)
+		}
+	})
+}
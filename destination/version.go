@@ -0,0 +1,78 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch"
+)
+
+// resolveVersion detects the version of the server for automatic version, otherwise verifies the configured one.
+// The generic client supports any version, so it is not verified.
+func (d *Destination) resolveVersion(ctx context.Context) error {
+	if d.config.Version == elasticsearch.VersionGeneric {
+		return nil
+	}
+
+	info, err := elasticsearch.DetectServer(ctx, d.config)
+	if err != nil {
+		if d.config.Version == elasticsearch.VersionAuto {
+			return fmt.Errorf("server version could not be detected: %w", err)
+		}
+
+		// The root endpoint may be forbidden for the configured user, the configured version is trusted then
+		sdk.Logger(ctx).Warn().Err(err).Msg("server version could not be verified")
+
+		return nil
+	}
+
+	d.serverInfo = info
+
+	version, err := info.ClientVersion()
+
+	if d.config.Version != elasticsearch.VersionAuto {
+		if err != nil || version != d.config.Version {
+			return fmt.Errorf("%q config value %s does not match the server version: %s", ConfigKeyVersion, d.config.Version, info)
+		}
+
+		return d.checkServerSupport()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sdk.Logger(ctx).Info().Msgf("detected server version: %s", info)
+
+	if err := d.config.resolveVersion(version); err != nil {
+		return err
+	}
+
+	return d.checkServerSupport()
+}
+
+// checkServerSupport validates config values supported only since a minor version of the server,
+// which the configured major version does not tell.
+func (d *Destination) checkServerSupport() error {
+	// Index lifecycle management was introduced in Elasticsearch 6.6
+	if d.config.ILMPolicy != "" && d.serverInfo.Before(6, 6) {
+		return fmt.Errorf("%q config value is supported only for versions 6.6 and newer: %s", ConfigKeyILMPolicy, d.serverInfo)
+	}
+
+	return nil
+}
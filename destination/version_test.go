@@ -0,0 +1,151 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestination_ResolveVersion(t *testing.T) {
+	newServer := func(t *testing.T, status int, body string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	newDestination := func(t *testing.T, version, host string, extra ...string) *Destination {
+		cfg := map[string]string{
+			ConfigKeyVersion:  version,
+			ConfigKeyHost:     host,
+			ConfigKeyIndex:    "users",
+			ConfigKeyBulkSize: "1",
+		}
+		for n := 0; n+1 < len(extra); n += 2 {
+			cfg[extra[n]] = extra[n+1]
+		}
+
+		config, err := ParseConfig(cfg)
+		require.NoError(t, err)
+
+		return &Destination{
+			config: config,
+		}
+	}
+
+	t.Run("Detects version of the server for automatic version", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, `{"version":{"number":"8.4.1"},"tagline":"You Know, for Search"}`)
+		destination := newDestination(t, elasticsearch.VersionAuto, server.URL)
+
+		require.NoError(t, destination.resolveVersion(context.Background()))
+		require.Equal(t, elasticsearch.Version8, destination.config.Version)
+	})
+
+	t.Run("Detects OpenSearch for automatic version", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, `{"version":{"distribution":"opensearch","number":"1.3.13"}}`)
+		destination := newDestination(t, elasticsearch.VersionAuto, server.URL)
+
+		require.NoError(t, destination.resolveVersion(context.Background()))
+		require.Equal(t, elasticsearch.VersionOpenSearch1, destination.config.Version)
+	})
+
+	t.Run("Fails when version of the server is unsupported for automatic version", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, `{"version":{"number":"4.6.1"}}`)
+		destination := newDestination(t, elasticsearch.VersionAuto, server.URL)
+
+		require.EqualError(t, destination.resolveVersion(context.Background()), "unsupported server version: Elasticsearch 4.6.1")
+	})
+
+	t.Run("Fails when version of the server could not be detected for automatic version", func(t *testing.T) {
+		server := newServer(t, http.StatusForbidden, "")
+		destination := newDestination(t, elasticsearch.VersionAuto, server.URL)
+
+		require.EqualError(t, destination.resolveVersion(context.Background()), "server version could not be detected: 403 Forbidden")
+	})
+
+	t.Run("Fails when configured version does not match the server", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, `{"version":{"number":"8.4.1"},"tagline":"You Know, for Search"}`)
+		destination := newDestination(t, elasticsearch.Version7, server.URL)
+
+		require.EqualError(
+			t,
+			destination.resolveVersion(context.Background()),
+			fmt.Sprintf("%q config value 7 does not match the server version: Elasticsearch 8.4.1", ConfigKeyVersion),
+		)
+	})
+
+	t.Run("Detects generic version for Elasticsearch newer than dedicated clients", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, `{"version":{"number":"9.0.0"},"tagline":"You Know, for Search"}`)
+		destination := newDestination(t, elasticsearch.VersionAuto, server.URL)
+
+		require.NoError(t, destination.resolveVersion(context.Background()))
+		require.Equal(t, elasticsearch.VersionGeneric, destination.config.Version)
+	})
+
+	t.Run("Does not verify generic version", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, `{"version":{"number":"8.4.1"},"tagline":"You Know, for Search"}`)
+		destination := newDestination(t, elasticsearch.VersionGeneric, server.URL)
+
+		require.NoError(t, destination.resolveVersion(context.Background()))
+		require.Equal(t, elasticsearch.VersionGeneric, destination.config.Version)
+	})
+
+	t.Run("Accepts configured version matching the server", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, `{"version":{"distribution":"opensearch","number":"2.11.0"}}`)
+		destination := newDestination(t, elasticsearch.VersionOpenSearch2, server.URL)
+
+		require.NoError(t, destination.resolveVersion(context.Background()))
+	})
+
+	t.Run("Fails when ILM policy is configured for Elasticsearch older than 6.6", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, `{"version":{"number":"6.5.4"},"tagline":"You Know, for Search"}`)
+
+		for _, version := range []string{elasticsearch.VersionAuto, elasticsearch.Version6} {
+			destination := newDestination(t, version, server.URL, ConfigKeyType, "user", ConfigKeyILMPolicy, "users-policy")
+
+			require.EqualError(
+				t,
+				destination.resolveVersion(context.Background()),
+				fmt.Sprintf("%q config value is supported only for versions 6.6 and newer: Elasticsearch 6.5.4", ConfigKeyILMPolicy),
+			)
+		}
+	})
+
+	t.Run("Accepts ILM policy for Elasticsearch 6.6", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, `{"version":{"number":"6.6.0"},"tagline":"You Know, for Search"}`)
+		destination := newDestination(t, elasticsearch.VersionAuto, server.URL, ConfigKeyType, "user", ConfigKeyILMPolicy, "users-policy")
+
+		require.NoError(t, destination.resolveVersion(context.Background()))
+		require.Equal(t, elasticsearch.Version6, destination.config.Version)
+	})
+
+	t.Run("Trusts configured version when version of the server could not be detected", func(t *testing.T) {
+		server := newServer(t, http.StatusForbidden, "")
+		destination := newDestination(t, elasticsearch.Version7, server.URL)
+
+		require.NoError(t, destination.resolveVersion(context.Background()))
+		require.Equal(t, elasticsearch.Version7, destination.config.Version)
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type Compression = string
+
+// Below is a list of all supported compressions of Bulk API request bodies.
+const (
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+)
+
+// CompressionHeaders are sent with gzip compressed request bodies.
+// Responses are requested compressed as well, and have to be decompressed with DecompressResponseBody.
+var CompressionHeaders = map[string]string{
+	"Content-Encoding": "gzip",
+	"Accept-Encoding":  "gzip",
+}
+
+// gzipWriters keeps gzip writers between requests, as each of them allocates large compression buffers.
+var gzipWriters = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(io.Discard)
+	},
+}
+
+// CompressRequestBody returns gzip compressed content of the reader.
+// Clients compress Bulk API request bodies with it rather than by the transport, so gzip writers are reused between requests.
+func CompressRequestBody(reader io.Reader) (*bytes.Buffer, error) {
+	writer := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(writer)
+
+	compressed := &bytes.Buffer{}
+	writer.Reset(compressed)
+
+	if _, err := io.Copy(writer, reader); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return compressed, nil
+}
+
+// DecompressResponseBody returns the reader of decompressed response body, when the response is gzip compressed.
+func DecompressResponseBody(header http.Header, body io.ReadCloser) (io.ReadCloser, error) {
+	if header.Get("Content-Encoding") != "gzip" {
+		return body, nil
+	}
+
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		_ = body.Close()
+
+		return nil, err
+	}
+
+	return &gzipReadCloser{Reader: reader, body: body}, nil
+}
+
+// gzipReadCloser closes both the gzip reader and the underlying response body.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (r *gzipReadCloser) Close() error {
+	if err := r.Reader.Close(); err != nil {
+		_ = r.body.Close()
+
+		return err
+	}
+
+	return r.body.Close()
+}
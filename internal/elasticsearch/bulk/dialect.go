@@ -0,0 +1,72 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+// Dialect describes how a version of the Bulk API expects operations to be encoded.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html
+type Dialect struct {
+	// CreateAction is the action inserting Documents with IDs generated by the server.
+	// Elasticsearch v5 and v6 use the index action, newer versions use the create action.
+	CreateAction string
+
+	// IndexAction is the action creating or replacing Documents with given IDs.
+	IndexAction string
+
+	// UpdateAction is the action partially updating Documents with given IDs.
+	UpdateAction string
+
+	// DeleteAction is the action removing Documents with given IDs.
+	DeleteAction string
+
+	// DocumentType reports whether actions carry the `_type` metadata field, required by Elasticsearch v5 and v6.
+	DocumentType bool
+
+	// Upsert is the shape of the update action source creating missing Documents.
+	Upsert UpsertShape
+
+	// Params are the names of the optional action metadata parameters.
+	Params Params
+
+	// RetryOnConflict is the number of times an update action is retried on version conflicts.
+	// Zero omits the parameter, as does a Dialect not supporting it.
+	RetryOnConflict int
+}
+
+// UpsertShape is the shape of the update action source creating missing Documents.
+type UpsertShape int
+
+const (
+	// DocAsUpsert sends the Document once as `doc`, using it as the new Document with `doc_as_upsert`.
+	DocAsUpsert UpsertShape = iota
+
+	// DocAndUpsert sends the Document twice, as the partial `doc` and as the new `upsert` Document.
+	DocAndUpsert
+)
+
+// Params are the names of the optional action metadata parameters.
+// Empty name marks the parameter as not supported by the Dialect.
+type Params struct {
+	// Version is the name of the external Document version parameter.
+	// Elasticsearch v5 uses `_version`, newer versions use `version`.
+	Version string
+
+	// VersionType is the name of the Document version type parameter.
+	// Elasticsearch v5 uses `_version_type`, newer versions use `version_type`.
+	VersionType string
+
+	// RetryOnConflict is the name of the update action retries parameter.
+	// Elasticsearch v5 uses `_retry_on_conflict`, newer versions use `retry_on_conflict`.
+	RetryOnConflict string
+}
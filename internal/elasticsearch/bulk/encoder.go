@@ -0,0 +1,146 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// externalVersionType accepts Document versions provided by the source, as long as they do not go back in time.
+const externalVersionType = "external_gte"
+
+// Encoder prepares Bulk API operations for the configured index according to the Dialect.
+// Each operation consists of the action and metadata line, and the optional Document line.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html
+type Encoder struct {
+	dialect      Dialect
+	index        string
+	documentType string
+}
+
+// NewEncoder creates Encoder of operations on given index.
+// The document type is only used when the Dialect requires it, empty type is omitted.
+func NewEncoder(dialect Dialect, index, documentType string) Encoder {
+	return Encoder{
+		dialect:      dialect,
+		index:        index,
+		documentType: documentType,
+	}
+}
+
+// CreateOperation prepares insert operation definition. The Document ID is generated by the server.
+func (e Encoder) CreateOperation(item sdk.Record) (interface{}, interface{}, error) {
+	payload, err := preparePayload(&item)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return actionAndMetadata{e.dialect.CreateAction: e.metadata("")}, source(payload), nil
+}
+
+// UpsertOperation prepares upsert operation definition.
+// The Document is partially updated, or created when missing, according to the Dialect's upsert shape.
+func (e Encoder) UpsertOperation(key string, item sdk.Record) (interface{}, interface{}, error) {
+	payload, err := preparePayload(&item)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := e.metadata(key)
+	if e.dialect.RetryOnConflict > 0 && e.dialect.Params.RetryOnConflict != "" {
+		metadata[e.dialect.Params.RetryOnConflict] = e.dialect.RetryOnConflict
+	}
+
+	return actionAndMetadata{e.dialect.UpdateAction: metadata}, e.upsertSource(payload), nil
+}
+
+// IndexOperation prepares index (create or replace) operation definition.
+// Non-zero version is used as the external version of the Document.
+func (e Encoder) IndexOperation(key string, item sdk.Record, version uint64) (interface{}, interface{}, error) {
+	payload, err := preparePayload(&item)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata, err := e.versionedMetadata(e.dialect.IndexAction, key, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return actionAndMetadata{e.dialect.IndexAction: metadata}, source(payload), nil
+}
+
+// DeleteOperation prepares delete operation definition.
+// Non-zero version is used as the external version of the Document.
+func (e Encoder) DeleteOperation(key string, version uint64) (interface{}, error) {
+	metadata, err := e.versionedMetadata(e.dialect.DeleteAction, key, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return actionAndMetadata{e.dialect.DeleteAction: metadata}, nil
+}
+
+func (e Encoder) metadata(key string) actionMetadata {
+	metadata := actionMetadata{"_index": e.index}
+
+	if key != "" {
+		metadata["_id"] = key
+	}
+
+	if e.dialect.DocumentType && e.documentType != "" {
+		metadata["_type"] = e.documentType
+	}
+
+	return metadata
+}
+
+func (e Encoder) versionedMetadata(action, key string, version uint64) (actionMetadata, error) {
+	metadata := e.metadata(key)
+
+	if version > 0 {
+		if e.dialect.Params.Version == "" || e.dialect.Params.VersionType == "" {
+			return nil, fmt.Errorf("external versions are not supported by the %q action", action)
+		}
+
+		metadata[e.dialect.Params.Version] = version
+		metadata[e.dialect.Params.VersionType] = externalVersionType
+	}
+
+	return metadata, nil
+}
+
+func (e Encoder) upsertSource(payload json.RawMessage) updateSource {
+	if e.dialect.Upsert == DocAndUpsert {
+		return updateSource{Doc: payload, Upsert: payload}
+	}
+
+	return updateSource{Doc: payload, DocAsUpsert: true}
+}
+
+// preparePayload encodes Record's payload as JSON.
+func preparePayload(item *sdk.Record) (json.RawMessage, error) {
+	switch itemPayload := item.Payload.(type) {
+	case sdk.StructuredData:
+		return json.Marshal(itemPayload)
+
+	default:
+		// Nothing more can be done, we can trust the source to provide valid JSON
+		return itemPayload.Bytes(), nil
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	legacyDialect = Dialect{
+		CreateAction: "index",
+		IndexAction:  "index",
+		UpdateAction: "update",
+		DeleteAction: "delete",
+		DocumentType: true,
+		Params: Params{
+			Version:         "_version",
+			VersionType:     "_version_type",
+			RetryOnConflict: "_retry_on_conflict",
+		},
+	}
+	currentDialect = Dialect{
+		CreateAction: "create",
+		IndexAction:  "index",
+		UpdateAction: "update",
+		DeleteAction: "delete",
+		Params: Params{
+			Version:         "version",
+			VersionType:     "version_type",
+			RetryOnConflict: "retry_on_conflict",
+		},
+		RetryOnConflict: 3,
+	}
+)
+
+func TestEncoder_CreateOperation(t *testing.T) {
+	t.Run("Uses create action of the dialect", func(t *testing.T) {
+		metadata, payload, err := NewEncoder(currentDialect, "users", "user").CreateOperation(sdk.Record{
+			Payload: sdk.StructuredData{"name": "John"},
+		})
+
+		require.NoError(t, err)
+		requireJSON(t, `{"create":{"_index":"users"}}`, metadata)
+		requireJSON(t, `{"name":"John"}`, payload)
+	})
+
+	t.Run("Sets document type when required by the dialect", func(t *testing.T) {
+		metadata, payload, err := NewEncoder(legacyDialect, "users", "user").CreateOperation(sdk.Record{
+			Payload: sdk.RawData(`{"name":"John"}`),
+		})
+
+		require.NoError(t, err)
+		requireJSON(t, `{"index":{"_index":"users","_type":"user"}}`, metadata)
+		requireJSON(t, `{"name":"John"}`, payload)
+	})
+
+	t.Run("Fails when payload could not be prepared", func(t *testing.T) {
+		metadata, payload, err := NewEncoder(currentDialect, "users", "").CreateOperation(sdk.Record{
+			Payload: sdk.StructuredData{
+				"foo": complex64(1 + 2i),
+			},
+		})
+
+		require.Nil(t, metadata)
+		require.Nil(t, payload)
+		require.EqualError(t, err, "json: unsupported type: complex64")
+	})
+}
+
+func TestEncoder_UpsertOperation(t *testing.T) {
+	t.Run("Sets retry on conflict of the dialect", func(t *testing.T) {
+		metadata, payload, err := NewEncoder(currentDialect, "users", "").UpsertOperation("key", sdk.Record{
+			Payload: sdk.StructuredData{"name": "John"},
+		})
+
+		require.NoError(t, err)
+		requireJSON(t, `{"update":{"_id":"key","_index":"users","retry_on_conflict":3}}`, metadata)
+		requireJSON(t, `{"doc":{"name":"John"},"doc_as_upsert":true}`, payload)
+	})
+
+	t.Run("Omits retry on conflict when not set by the dialect", func(t *testing.T) {
+		metadata, _, err := NewEncoder(legacyDialect, "users", "user").UpsertOperation("key", sdk.Record{
+			Payload: sdk.StructuredData{"name": "John"},
+		})
+
+		require.NoError(t, err)
+		requireJSON(t, `{"update":{"_id":"key","_index":"users","_type":"user"}}`, metadata)
+	})
+
+	t.Run("Omits retry on conflict when not supported by the dialect", func(t *testing.T) {
+		dialect := currentDialect
+		dialect.Params.RetryOnConflict = ""
+
+		metadata, _, err := NewEncoder(dialect, "users", "").UpsertOperation("key", sdk.Record{
+			Payload: sdk.StructuredData{"name": "John"},
+		})
+
+		require.NoError(t, err)
+		requireJSON(t, `{"update":{"_id":"key","_index":"users"}}`, metadata)
+	})
+
+	t.Run("Uses update action and upsert shape of the dialect", func(t *testing.T) {
+		dialect := currentDialect
+		dialect.UpdateAction = "upsert"
+		dialect.Upsert = DocAndUpsert
+
+		metadata, payload, err := NewEncoder(dialect, "users", "").UpsertOperation("key", sdk.Record{
+			Payload: sdk.StructuredData{"name": "John"},
+		})
+
+		require.NoError(t, err)
+		requireJSON(t, `{"upsert":{"_id":"key","_index":"users","retry_on_conflict":3}}`, metadata)
+		requireJSON(t, `{"doc":{"name":"John"},"upsert":{"name":"John"}}`, payload)
+	})
+}
+
+func TestEncoder_IndexOperation(t *testing.T) {
+	t.Run("Sets external version when provided", func(t *testing.T) {
+		metadata, payload, err := NewEncoder(currentDialect, "users", "").IndexOperation("key", sdk.Record{
+			Payload: sdk.StructuredData{"name": "John"},
+		}, 1665000000000)
+
+		require.NoError(t, err)
+		requireJSON(t, `{"index":{"_id":"key","_index":"users","version":1665000000000,"version_type":"external_gte"}}`, metadata)
+		requireJSON(t, `{"name":"John"}`, payload)
+	})
+
+	t.Run("Omits version when not provided", func(t *testing.T) {
+		metadata, _, err := NewEncoder(legacyDialect, "users", "user").IndexOperation("key", sdk.Record{
+			Payload: sdk.StructuredData{"name": "John"},
+		}, 0)
+
+		require.NoError(t, err)
+		requireJSON(t, `{"index":{"_id":"key","_index":"users","_type":"user"}}`, metadata)
+	})
+
+	t.Run("Fails when external versions are not supported by the dialect", func(t *testing.T) {
+		dialect := currentDialect
+		dialect.IndexAction = "put"
+		dialect.Params.Version = ""
+
+		metadata, payload, err := NewEncoder(dialect, "users", "").IndexOperation("key", sdk.Record{
+			Payload: sdk.StructuredData{"name": "John"},
+		}, 1665000000000)
+
+		require.Nil(t, metadata)
+		require.Nil(t, payload)
+		require.EqualError(t, err, `external versions are not supported by the "put" action`)
+	})
+}
+
+func TestEncoder_DeleteOperation(t *testing.T) {
+	t.Run("Sets external version when provided", func(t *testing.T) {
+		metadata, err := NewEncoder(legacyDialect, "users", "user").DeleteOperation("key", 1665000000000)
+
+		require.NoError(t, err)
+		requireJSON(t, `{"delete":{"_id":"key","_index":"users","_type":"user","_version":1665000000000,"_version_type":"external_gte"}}`, metadata)
+	})
+
+	t.Run("Uses delete action of the dialect", func(t *testing.T) {
+		dialect := currentDialect
+		dialect.DeleteAction = "remove"
+
+		metadata, err := NewEncoder(dialect, "users", "").DeleteOperation("key", 0)
+
+		require.NoError(t, err)
+		requireJSON(t, `{"remove":{"_id":"key","_index":"users"}}`, metadata)
+	})
+}
+
+func requireJSON(t *testing.T, expected string, value interface{}) {
+	t.Helper()
+
+	actual, err := json.Marshal(value)
+
+	require.NoError(t, err)
+	require.JSONEq(t, expected, string(actual))
+}
@@ -0,0 +1,36 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import "encoding/json"
+
+// actionAndMetadata is the action line of an operation, keyed by the action name.
+type actionAndMetadata map[string]actionMetadata
+
+// actionMetadata holds the metadata parameters of an action, named according to the Dialect.
+type actionMetadata map[string]interface{}
+
+// source is the Document line of create and index operations, sent as is.
+type source []byte
+
+func (s source) MarshalJSON() ([]byte, error) {
+	return s, nil
+}
+
+type updateSource struct {
+	Doc         json.RawMessage `json:"doc"`
+	DocAsUpsert bool            `json:"doc_as_upsert,omitempty"`
+	Upsert      json.RawMessage `json:"upsert,omitempty"`
+}
@@ -31,12 +31,60 @@ type Client interface {
 	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html
 	Bulk(ctx context.Context, reader io.Reader) (io.ReadCloser, error)
 
+	// IndexExists checks if the configured index exists.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-exists.html
+	IndexExists(ctx context.Context) (bool, error)
+
+	// CreateIndex creates the configured index with given settings and mappings.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-create-index.html
+	CreateIndex(ctx context.Context, body io.Reader) error
+
+	// GetMapping returns the mapping definition of the configured index.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-get-mapping.html
+	GetMapping(ctx context.Context) (map[string]interface{}, error)
+
+	// GetIndexTemplate returns the index template with given name, or nil when it does not exist.
+	// Elasticsearch v5 and v6 use legacy index templates, newer versions use composable index templates.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-get-template.html
+	GetIndexTemplate(ctx context.Context, name string) (map[string]interface{}, error)
+
+	// PutIndexTemplate creates or replaces the index template with given name.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-put-template.html
+	PutIndexTemplate(ctx context.Context, name string, body io.Reader) error
+
+	// GetComponentTemplate returns the component template with given name, or nil when it does not exist.
+	// Component templates are not supported by Elasticsearch v5 and v6.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/getting-component-templates.html
+	GetComponentTemplate(ctx context.Context, name string) (map[string]interface{}, error)
+
+	// PutComponentTemplate creates or replaces the component template with given name.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-component-template.html
+	PutComponentTemplate(ctx context.Context, name string, body io.Reader) error
+
+	// PutLifecyclePolicy creates or updates the index lifecycle policy with given name.
+	// Index lifecycle management is not supported by Elasticsearch v5.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-put-lifecycle.html
+	PutLifecyclePolicy(ctx context.Context, name string, body io.Reader) error
+
+	// CreateRolloverIndex creates the first index of the rollover series, named after the configured index
+	// with the `-000001` suffix. The configured index name is expected to be the write alias set in the body.
+	// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/getting-started-index-lifecycle-management.html
+	CreateRolloverIndex(ctx context.Context, body io.Reader) error
+
+	// CloseIdleConnections closes HTTP connections which are not in use.
+	CloseIdleConnections()
+
 	// PrepareCreateOperation prepares insert operation definition for Bulk API query.
 	PrepareCreateOperation(item sdk.Record) (metadata interface{}, payload interface{}, err error)
 
 	// PrepareUpsertOperation prepares upsert operation definition for Bulk API query.
 	PrepareUpsertOperation(key string, item sdk.Record) (metadata interface{}, payload interface{}, err error)
 
+	// PrepareIndexOperation prepares index (create or replace) operation definition for Bulk API query.
+	// Non-zero version is used as the external version of the Document.
+	PrepareIndexOperation(key string, item sdk.Record, version uint64) (metadata interface{}, payload interface{}, err error)
+
 	// PrepareDeleteOperation prepares delete operation definition for Bulk API query.
-	PrepareDeleteOperation(key string) (metadata interface{}, err error)
+	// Non-zero version is used as the external version of the Document.
+	PrepareDeleteOperation(key string, version uint64) (metadata interface{}, err error)
 }
@@ -0,0 +1,185 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/elastic/go-elasticsearch/v7/estransport"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+)
+
+// distributionOpenSearch is the distribution reported by OpenSearch, Elasticsearch does not report any.
+const distributionOpenSearch = "opensearch"
+
+//go:generate moq -out detection_config_moq_test.go . detectionConfig
+type detectionConfig interface {
+	GetHosts() []string
+	GetUsername() string
+	GetPassword() string
+	GetAPIKey() string
+	GetServiceToken() string
+	GetBearerToken() string
+	GetCertificateFingerprint() string
+	GetTLSConfig() *tls.Config
+	GetProxy() internal.ProxyFunc
+	GetHeader() http.Header
+	GetSigner() *sigv4.Signer
+}
+
+// ServerInfo describes the server as reported by its root endpoint.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/rest-api-root.html
+type ServerInfo struct {
+	Version struct {
+		Number       string `json:"number"`
+		Distribution string `json:"distribution"`
+	} `json:"version"`
+}
+
+// String returns the product name and the version of the server, e.g. "Elasticsearch 8.4.1".
+func (i ServerInfo) String() string {
+	if i.Version.Distribution == distributionOpenSearch {
+		return "OpenSearch " + i.Version.Number
+	}
+
+	return "Elasticsearch " + i.Version.Number
+}
+
+// Before reports whether the server version is older than given major and minor version.
+// Unparsable versions are never reported as older.
+func (i ServerInfo) Before(major, minor int) bool {
+	parts := strings.SplitN(i.Version.Number, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+
+	majorParsed, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+
+	minorParsed, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+
+	return majorParsed < major || (majorParsed == major && minorParsed < minor)
+}
+
+// ClientVersion returns the version of the client which supports the server.
+// Elasticsearch majors newer than the dedicated clients are supported by the generic client.
+func (i ServerInfo) ClientVersion() (Version, error) {
+	major, _, _ := strings.Cut(i.Version.Number, ".")
+
+	if i.Version.Distribution != distributionOpenSearch {
+		if majorParsed, err := strconv.Atoi(major); err == nil && majorParsed > 8 {
+			return VersionGeneric, nil
+		}
+	}
+
+	var supported []Version
+	if i.Version.Distribution == distributionOpenSearch {
+		supported = []Version{VersionOpenSearch1, VersionOpenSearch2}
+		major = distributionOpenSearch + major
+	} else {
+		supported = []Version{Version5, Version6, Version7, Version8}
+	}
+
+	for _, version := range supported {
+		if version == major {
+			return version, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported server version: %s", i)
+}
+
+// DetectServer requests the root endpoint of the server with connection settings of given config.
+// The product check of go-elasticsearch is not performed, so OpenSearch is detected as well.
+func DetectServer(ctx context.Context, config interface{}) (ServerInfo, error) {
+	configTyped, ok := config.(detectionConfig)
+	if !ok {
+		return ServerInfo{}, errors.New("provided config object is invalid")
+	}
+
+	urls := make([]*url.URL, 0, len(configTyped.GetHosts()))
+	for _, host := range configTyped.GetHosts() {
+		u, err := url.Parse(strings.TrimRight(host, "/"))
+		if err != nil {
+			return ServerInfo{}, fmt.Errorf("cannot parse url: %w", err)
+		}
+
+		urls = append(urls, u)
+	}
+
+	// Both Elasticsearch service tokens and OpenSearch bearer tokens are sent as bearer tokens
+	bearerToken := configTyped.GetServiceToken()
+	if bearerToken == "" {
+		bearerToken = configTyped.GetBearerToken()
+	}
+
+	transport, err := estransport.New(estransport.Config{
+		URLs:                   urls,
+		Username:               configTyped.GetUsername(),
+		Password:               configTyped.GetPassword(),
+		APIKey:                 configTyped.GetAPIKey(),
+		ServiceToken:           bearerToken,
+		CertificateFingerprint: configTyped.GetCertificateFingerprint(),
+		Header:                 configTyped.GetHeader(),
+		DisableMetaHeader:      true,
+		Transport: sigv4.Transport(
+			internal.NewTransport(configTyped.GetTLSConfig(), configTyped.GetProxy()),
+			configTyped.GetSigner(),
+		),
+	})
+	if err != nil {
+		return ServerInfo{}, fmt.Errorf("error creating transport: %w", err)
+	}
+
+	result, err := esapi.InfoRequest{}.Do(ctx, transport)
+	if err != nil {
+		return ServerInfo{}, &internal.TransportError{Err: err}
+	}
+
+	defer result.Body.Close()
+
+	if result.IsError() {
+		return ServerInfo{}, &internal.ResponseError{
+			StatusCode: result.StatusCode,
+			Reason:     result.Status(),
+		}
+	}
+
+	var info ServerInfo
+	if err := json.NewDecoder(result.Body).Decode(&info); err != nil {
+		return ServerInfo{}, fmt.Errorf("failed to decode the server info: %w", err)
+	}
+
+	if info.Version.Number == "" {
+		return ServerInfo{}, errors.New("server did not report its version")
+	}
+
+	return info, nil
+}
@@ -0,0 +1,175 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerInfo_ClientVersion(t *testing.T) {
+	for _, tt := range []struct {
+		number       string
+		distribution string
+		version      Version
+	}{
+		{number: "5.6.16", version: Version5},
+		{number: "6.8.23", version: Version6},
+		{number: "7.17.3", version: Version7},
+		{number: "8.4.1", version: Version8},
+		{number: "9.0.0", version: VersionGeneric},
+		{number: "1.3.13", distribution: "opensearch", version: VersionOpenSearch1},
+		{number: "2.11.0", distribution: "opensearch", version: VersionOpenSearch2},
+	} {
+		t.Run(fmt.Sprintf("Returns version %s for %s %s", tt.version, tt.distribution, tt.number), func(t *testing.T) {
+			var info ServerInfo
+			info.Version.Number = tt.number
+			info.Version.Distribution = tt.distribution
+
+			version, err := info.ClientVersion()
+
+			require.NoError(t, err)
+			require.Equal(t, tt.version, version)
+		})
+	}
+
+	t.Run("Fails for unsupported server version", func(t *testing.T) {
+		var info ServerInfo
+		info.Version.Number = "3.0.0"
+		info.Version.Distribution = "opensearch"
+
+		version, err := info.ClientVersion()
+
+		require.Empty(t, version)
+		require.EqualError(t, err, "unsupported server version: OpenSearch 3.0.0")
+	})
+}
+
+func TestServerInfo_Before(t *testing.T) {
+	for _, tt := range []struct {
+		number string
+		before bool
+	}{
+		{number: "7.7.1", before: true},
+		{number: "6.8.23", before: true},
+		{number: "7.8.0", before: false},
+		{number: "7.17.3", before: false},
+		{number: "8.0.0-SNAPSHOT", before: false},
+		{number: "", before: false},
+	} {
+		t.Run(fmt.Sprintf("Reports %q before 7.8 as %t", tt.number, tt.before), func(t *testing.T) {
+			var info ServerInfo
+			info.Version.Number = tt.number
+
+			require.Equal(t, tt.before, info.Before(7, 8))
+		})
+	}
+}
+
+func TestDetectServer(t *testing.T) {
+	newConfig := func(host string) *detectionConfigMock {
+		return &detectionConfigMock{
+			GetHostsFunc: func() []string {
+				return []string{host}
+			},
+			GetUsernameFunc: func() string {
+				return "elastic"
+			},
+			GetPasswordFunc: func() string {
+				return "secret"
+			},
+			GetAPIKeyFunc: func() string {
+				return ""
+			},
+			GetServiceTokenFunc: func() string {
+				return ""
+			},
+			GetBearerTokenFunc: func() string {
+				return ""
+			},
+			GetCertificateFingerprintFunc: func() string {
+				return ""
+			},
+			GetTLSConfigFunc: func() *tls.Config {
+				return nil
+			},
+			GetProxyFunc: func() internal.ProxyFunc {
+				return nil
+			},
+			GetHeaderFunc: func() http.Header {
+				return nil
+			},
+			GetSignerFunc: func() *sigv4.Signer {
+				return nil
+			},
+		}
+	}
+
+	t.Run("Fails when provided config object is invalid", func(t *testing.T) {
+		_, err := DetectServer(context.Background(), "invalid config object")
+
+		require.EqualError(t, err, "provided config object is invalid")
+	})
+
+	t.Run("Returns info of OpenSearch server", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if username, password, ok := r.BasicAuth(); !ok || username != "elastic" || password != "secret" || r.URL.Path != "/" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"version":{"distribution":"opensearch","number":"2.11.0"},"tagline":"The OpenSearch Project: https://opensearch.org/"}`))
+		}))
+		defer server.Close()
+
+		info, err := DetectServer(context.Background(), newConfig(server.URL))
+
+		require.NoError(t, err)
+		require.Equal(t, "OpenSearch 2.11.0", info.String())
+	})
+
+	t.Run("Fails with response error when server responds with error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		_, err := DetectServer(context.Background(), newConfig(server.URL))
+
+		var responseErr *internal.ResponseError
+		require.ErrorAs(t, err, &responseErr)
+		require.Equal(t, http.StatusForbidden, responseErr.StatusCode)
+	})
+
+	t.Run("Fails when server does not report its version", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"tagline":"You Know, for Search"}`))
+		}))
+		defer server.Close()
+
+		_, err := DetectServer(context.Background(), newConfig(server.URL))
+
+		require.EqualError(t, err, "server did not report its version")
+	})
+}
@@ -17,6 +17,8 @@ package elasticsearch
 import (
 	"fmt"
 
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/generic"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/opensearch"
 	v5 "github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/v5"
 	v6 "github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/v6"
 	v7 "github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/v7"
@@ -30,6 +32,15 @@ const (
 	Version6 Version = "6"
 	Version7 Version = "7"
 	Version8 Version = "8"
+
+	VersionOpenSearch1 Version = "opensearch1"
+	VersionOpenSearch2 Version = "opensearch2"
+
+	// VersionGeneric supports any server speaking the stable REST API, including majors without a dedicated client.
+	VersionGeneric Version = "generic"
+
+	// VersionAuto is resolved to one of the versions above by DetectServer.
+	VersionAuto Version = "auto"
 )
 
 var (
@@ -37,9 +48,15 @@ var (
 	v6ClientBuilder = v6.NewClient
 	v7ClientBuilder = v7.NewClient
 	v8ClientBuilder = v8.NewClient
+
+	openSearch1ClientBuilder = opensearch.NewClient
+	openSearch2ClientBuilder = opensearch.NewClient
+
+	genericClientBuilder = generic.NewClient
 )
 
 // NewClient creates new Elasticsearch client which supports given server version.
+// OpenSearch 1.x and 2.x share the client, as both accept the same API.
 // Returns error when provided version is unsupported or client initialization failed.
 func NewClient(version Version, config interface{}) (Client, error) {
 	switch version {
@@ -55,6 +72,15 @@ func NewClient(version Version, config interface{}) (Client, error) {
 	case Version8:
 		return v8ClientBuilder(config)
 
+	case VersionOpenSearch1:
+		return openSearch1ClientBuilder(config)
+
+	case VersionOpenSearch2:
+		return openSearch2ClientBuilder(config)
+
+	case VersionGeneric:
+		return genericClientBuilder(config)
+
 	default:
 		return nil, fmt.Errorf("unsupported version: %s", version)
 	}
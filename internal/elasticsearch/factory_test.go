@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	"github.com/jaswdr/faker"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/generic"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/opensearch"
 	v5 "github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/v5"
 	v6 "github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/v6"
 	v7 "github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/v7"
@@ -121,4 +123,64 @@ func TestNewClient(t *testing.T) {
 		require.NoError(t, err)
 		require.Same(t, clientMock, client)
 	})
+
+	t.Run(fmt.Sprintf("Client for version %s can be created", VersionOpenSearch1), func(t *testing.T) {
+		var (
+			config = map[string]interface{}{
+				fakerInstance.Lorem().Word(): fakerInstance.Int(),
+			}
+			clientMock = new(opensearch.Client)
+		)
+
+		openSearch1ClientBuilder = func(cfg interface{}) (*opensearch.Client, error) {
+			require.Equal(t, config, cfg)
+
+			return clientMock, nil
+		}
+
+		client, err := NewClient(VersionOpenSearch1, config)
+
+		require.NoError(t, err)
+		require.Same(t, clientMock, client)
+	})
+
+	t.Run(fmt.Sprintf("Client for version %s can be created", VersionOpenSearch2), func(t *testing.T) {
+		var (
+			config = map[string]interface{}{
+				fakerInstance.Lorem().Word(): fakerInstance.Int(),
+			}
+			clientMock = new(opensearch.Client)
+		)
+
+		openSearch2ClientBuilder = func(cfg interface{}) (*opensearch.Client, error) {
+			require.Equal(t, config, cfg)
+
+			return clientMock, nil
+		}
+
+		client, err := NewClient(VersionOpenSearch2, config)
+
+		require.NoError(t, err)
+		require.Same(t, clientMock, client)
+	})
+
+	t.Run(fmt.Sprintf("Client for version %s can be created", VersionGeneric), func(t *testing.T) {
+		var (
+			config = map[string]interface{}{
+				fakerInstance.Lorem().Word(): fakerInstance.Int(),
+			}
+			clientMock = new(generic.Client)
+		)
+
+		genericClientBuilder = func(cfg interface{}) (*generic.Client, error) {
+			require.Equal(t, config, cfg)
+
+			return clientMock, nil
+		}
+
+		client, err := NewClient(VersionGeneric, config)
+
+		require.NoError(t, err)
+		require.Same(t, clientMock, client)
+	})
 }
@@ -0,0 +1,507 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/bulk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/response"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+)
+
+// compatibleWith is the major version of the REST API the client is written against.
+// REST compatibility spans a single major, so servers of this and the next major are asked for this API.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/rest-api-compatibility.html
+const compatibleWith = 8
+
+// compatibilityRetryInterval is how long requests are sent without REST compatibility
+// before the version of a server which did not report it is requested again.
+const compatibilityRetryInterval = time.Minute
+
+// NewClient creates the client of any Elasticsearch version newer than 7.8 and of OpenSearch.
+// It speaks the stable REST API with plain HTTP requests, so new majors work without a dedicated client.
+func NewClient(cfg interface{}) (*Client, error) {
+	configTyped, ok := cfg.(config)
+	if !ok {
+		return nil, errors.New("provided config object is invalid")
+	}
+
+	hosts := make([]*url.URL, 0, len(configTyped.GetHosts()))
+	for _, host := range configTyped.GetHosts() {
+		u, err := url.Parse(strings.TrimRight(host, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create client: cannot parse url: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("cannot create client: url %q must have scheme and host", host)
+		}
+
+		hosts = append(hosts, u)
+	}
+
+	return &Client{
+		http: &http.Client{
+			Transport: sigv4.Transport(
+				internal.NewTransport(configTyped.GetTLSConfig(), configTyped.GetProxy()),
+				configTyped.GetSigner(),
+			),
+		},
+		hosts: hosts,
+		cfg:   configTyped,
+	}, nil
+}
+
+type Client struct {
+	http  *http.Client
+	hosts []*url.URL
+	cfg   config
+
+	// next is the counter of requests, used to balance them between hosts in round-robin fashion
+	next uint32
+
+	// compatibility is the major version of the REST API requested from the server, or 0 when not supported.
+	// It is resolved from the root endpoint before the first request.
+	compatibility         int
+	compatibilityResolved bool
+	compatibilityRetryAt  time.Time
+	compatibilityMutex    sync.Mutex
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	result, err := c.perform(ctx, http.MethodHead, "/", nil, "")
+	if err != nil {
+		return err
+	}
+
+	_ = result.Body.Close()
+
+	if result.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("host ping failed: %s", result.Status)
+	}
+
+	return nil
+}
+
+func (c *Client) Bulk(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+	header := make(map[string]string)
+
+	if c.cfg.GetCompression() == internal.CompressionGzip {
+		compressed, err := internal.CompressRequestBody(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
+		}
+
+		reader = compressed
+
+		for name, value := range internal.CompressionHeaders {
+			header[name] = value
+		}
+	}
+
+	if opaqueID := internal.OpaqueID(ctx); opaqueID != "" {
+		header[internal.OpaqueIDHeader] = opaqueID
+	}
+
+	result, err := c.performWithHeader(ctx, http.MethodPost, "/_bulk", reader, "x-ndjson", header)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Body, err = internal.DecompressResponseBody(result.Header, result.Body); err != nil {
+		return nil, &internal.TransportError{Err: err}
+	}
+
+	if result.StatusCode >= http.StatusMultipleChoices {
+		return nil, response.ParseError(result.StatusCode, result.Status, result.Body)
+	}
+
+	return result.Body, nil
+}
+
+// Search executes Search API request on the configured index and returns the response body.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/search-search.html
+func (c *Client) Search(ctx context.Context, body io.Reader) (io.ReadCloser, error) {
+	result, err := c.perform(ctx, http.MethodPost, "/"+url.PathEscape(c.cfg.GetIndex())+"/_search", body, "json")
+	if err != nil {
+		return nil, err
+	}
+	if result.StatusCode >= http.StatusMultipleChoices {
+		return nil, response.ParseError(result.StatusCode, result.Status, result.Body)
+	}
+
+	return result.Body, nil
+}
+
+func (c *Client) IndexExists(ctx context.Context) (bool, error) {
+	result, err := c.perform(ctx, http.MethodHead, "/"+url.PathEscape(c.cfg.GetIndex()), nil, "")
+	if err != nil {
+		return false, err
+	}
+
+	switch result.StatusCode {
+	case http.StatusOK:
+		return true, result.Body.Close()
+
+	case http.StatusNotFound:
+		return false, result.Body.Close()
+
+	default:
+		return false, response.ParseError(result.StatusCode, result.Status, result.Body)
+	}
+}
+
+func (c *Client) CreateIndex(ctx context.Context, body io.Reader) error {
+	return c.put(ctx, "/"+url.PathEscape(c.cfg.GetIndex()), body)
+}
+
+func (c *Client) GetMapping(ctx context.Context) (map[string]interface{}, error) {
+	result, err := c.perform(ctx, http.MethodGet, "/"+url.PathEscape(c.cfg.GetIndex())+"/_mapping", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if result.StatusCode >= http.StatusMultipleChoices {
+		return nil, response.ParseError(result.StatusCode, result.Status, result.Body)
+	}
+
+	defer result.Body.Close()
+
+	var mappings map[string]struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if err := json.NewDecoder(result.Body).Decode(&mappings); err != nil {
+		return nil, fmt.Errorf("failed to decode the mapping: %w", err)
+	}
+
+	// The response is keyed by the concrete index name, which differs from the configured one for aliases
+	for _, index := range mappings {
+		return index.Mappings, nil
+	}
+
+	return nil, fmt.Errorf("mapping of %q index not found", c.cfg.GetIndex())
+}
+
+func (c *Client) GetIndexTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	var templates struct {
+		IndexTemplates []struct {
+			Name          string                 `json:"name"`
+			IndexTemplate map[string]interface{} `json:"index_template"`
+		} `json:"index_templates"`
+	}
+
+	if err := c.get(ctx, "/_index_template/"+url.PathEscape(name), &templates); err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates.IndexTemplates {
+		if template.Name == name {
+			return template.IndexTemplate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) PutIndexTemplate(ctx context.Context, name string, body io.Reader) error {
+	return c.put(ctx, "/_index_template/"+url.PathEscape(name), body)
+}
+
+func (c *Client) GetComponentTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	var templates struct {
+		ComponentTemplates []struct {
+			Name              string                 `json:"name"`
+			ComponentTemplate map[string]interface{} `json:"component_template"`
+		} `json:"component_templates"`
+	}
+
+	if err := c.get(ctx, "/_component_template/"+url.PathEscape(name), &templates); err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates.ComponentTemplates {
+		if template.Name == name {
+			return template.ComponentTemplate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) PutComponentTemplate(ctx context.Context, name string, body io.Reader) error {
+	return c.put(ctx, "/_component_template/"+url.PathEscape(name), body)
+}
+
+func (c *Client) PutLifecyclePolicy(ctx context.Context, name string, body io.Reader) error {
+	return c.put(ctx, "/_ilm/policy/"+url.PathEscape(name), body)
+}
+
+func (c *Client) CreateRolloverIndex(ctx context.Context, body io.Reader) error {
+	return c.put(ctx, "/"+url.PathEscape(c.cfg.GetIndex()+"-000001"), body)
+}
+
+func (c *Client) CloseIdleConnections() {
+	c.http.CloseIdleConnections()
+}
+
+func (c *Client) PrepareCreateOperation(item sdk.Record) (interface{}, interface{}, error) {
+	return c.bulkEncoder().CreateOperation(item)
+}
+
+func (c *Client) PrepareUpsertOperation(key string, item sdk.Record) (interface{}, interface{}, error) {
+	return c.bulkEncoder().UpsertOperation(key, item)
+}
+
+func (c *Client) PrepareIndexOperation(key string, item sdk.Record, version uint64) (interface{}, interface{}, error) {
+	return c.bulkEncoder().IndexOperation(key, item, version)
+}
+
+func (c *Client) PrepareDeleteOperation(key string, version uint64) (interface{}, error) {
+	return c.bulkEncoder().DeleteOperation(key, version)
+}
+
+// bulkEncoder creates Bulk API operations encoder for the configured index.
+func (c *Client) bulkEncoder() bulk.Encoder {
+	return bulk.NewEncoder(bulkDialect, c.cfg.GetIndex(), "")
+}
+
+// get decodes the JSON response of GET request into given value.
+// The value is left untouched when the resource was not found.
+func (c *Client) get(ctx context.Context, path string, value interface{}) error {
+	result, err := c.perform(ctx, http.MethodGet, path, nil, "")
+	if err != nil {
+		return err
+	}
+	if result.StatusCode == http.StatusNotFound {
+		return result.Body.Close()
+	}
+	if result.StatusCode >= http.StatusMultipleChoices {
+		return response.ParseError(result.StatusCode, result.Status, result.Body)
+	}
+
+	defer result.Body.Close()
+
+	if err := json.NewDecoder(result.Body).Decode(value); err != nil {
+		return fmt.Errorf("failed to decode the response: %w", err)
+	}
+
+	return nil
+}
+
+// put sends PUT request with given JSON body.
+func (c *Client) put(ctx context.Context, path string, body io.Reader) error {
+	result, err := c.perform(ctx, http.MethodPut, path, body, "json")
+	if err != nil {
+		return err
+	}
+	if result.StatusCode >= http.StatusMultipleChoices {
+		return response.ParseError(result.StatusCode, result.Status, result.Body)
+	}
+
+	return result.Body.Close()
+}
+
+// perform sends the request with body of given media subtype, e.g. "json".
+func (c *Client) perform(ctx context.Context, method, path string, body io.Reader, subtype string) (*http.Response, error) {
+	return c.performWithHeader(ctx, method, path, body, subtype, nil)
+}
+
+// performWithHeader sends the request with additional headers, asking for REST compatibility when supported.
+func (c *Client) performWithHeader(
+	ctx context.Context,
+	method, path string,
+	body io.Reader,
+	subtype string,
+	header map[string]string,
+) (*http.Response, error) {
+	compatibility, err := c.restCompatibility(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.send(ctx, method, path, body, subtype, header, compatibility)
+}
+
+// send sends the request to the next host in round-robin fashion,
+// and to the following ones when the host could not be reached.
+func (c *Client) send(
+	ctx context.Context,
+	method, path string,
+	body io.Reader,
+	subtype string,
+	header map[string]string,
+	compatibility int,
+) (*http.Response, error) {
+	if len(c.hosts) == 0 {
+		return nil, &internal.TransportError{Err: errors.New("no hosts configured")}
+	}
+
+	// The body is buffered, so it can be sent again to another host
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	start := int(atomic.AddUint32(&c.next, 1))
+
+	var lastErr error
+
+	for i := range c.hosts {
+		host := c.hosts[(start+i)%len(c.hosts)]
+
+		req, err := http.NewRequestWithContext(ctx, method, host.String()+path, bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		c.setHeaders(req, compatibility, subtype, header)
+
+		result, err := c.http.Do(req)
+		if err == nil {
+			return result, nil
+		}
+
+		lastErr = err
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		sdk.Logger(ctx).Debug().Err(err).Str("host", host.Host).Msg("host could not be reached")
+	}
+
+	return nil, &internal.TransportError{Err: lastErr}
+}
+
+// setHeaders sets authentication, configured and content negotiation headers of the request.
+func (c *Client) setHeaders(req *http.Request, compatibility int, subtype string, header map[string]string) {
+	for name, values := range c.cfg.GetHeader() {
+		req.Header[name] = values
+	}
+
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+
+	// API key overrides service token, which overrides username and password
+	switch {
+	case c.cfg.GetAPIKey() != "":
+		req.Header.Set("Authorization", "ApiKey "+c.cfg.GetAPIKey())
+
+	case c.cfg.GetServiceToken() != "":
+		req.Header.Set("Authorization", "Bearer "+c.cfg.GetServiceToken())
+
+	case c.cfg.GetUsername() != "":
+		req.SetBasicAuth(c.cfg.GetUsername(), c.cfg.GetPassword())
+	}
+
+	req.Header.Set("Accept", mediaType("json", compatibility))
+
+	if subtype != "" {
+		req.Header.Set("Content-Type", mediaType(subtype, compatibility))
+	}
+}
+
+// restCompatibility returns the major version of the REST API requested from the server, or 0 when REST
+// compatibility is not supported by the server. It is resolved once the server reports its version.
+// When the server does not report it, requests are sent without REST compatibility
+// and the version is requested again after compatibilityRetryInterval.
+func (c *Client) restCompatibility(ctx context.Context) (int, error) {
+	c.compatibilityMutex.Lock()
+	defer c.compatibilityMutex.Unlock()
+
+	if c.compatibilityResolved || time.Now().Before(c.compatibilityRetryAt) {
+		return c.compatibility, nil
+	}
+
+	// Sent without REST compatibility, as it is not known yet
+	result, err := c.send(ctx, http.MethodGet, "/", nil, "", nil, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	defer result.Body.Close()
+
+	var info struct {
+		Version struct {
+			Number       string `json:"number"`
+			Distribution string `json:"distribution"`
+		} `json:"version"`
+	}
+
+	if result.StatusCode != http.StatusOK || json.NewDecoder(result.Body).Decode(&info) != nil || info.Version.Number == "" {
+		return c.postponeCompatibility(), nil
+	}
+
+	major, _, _ := strings.Cut(info.Version.Number, ".")
+
+	majorParsed, err := strconv.Atoi(major)
+	if err != nil {
+		return c.postponeCompatibility(), nil
+	}
+
+	c.compatibilityResolved = true
+
+	// OpenSearch and older versions of Elasticsearch do not support REST compatibility
+	if info.Version.Distribution == "opensearch" || majorParsed < compatibleWith {
+		return 0, nil
+	}
+
+	if majorParsed > compatibleWith+1 {
+		sdk.Logger(ctx).Warn().Msgf(
+			"server version %s is newer than REST API compatibility with version %d covers",
+			info.Version.Number,
+			compatibleWith,
+		)
+	}
+
+	c.compatibility = compatibleWith
+
+	return c.compatibility, nil
+}
+
+// postponeCompatibility caches the lack of REST compatibility until the version is requested again.
+// Must be called with compatibilityMutex locked.
+func (c *Client) postponeCompatibility() int {
+	c.compatibility = 0
+	c.compatibilityRetryAt = time.Now().Add(compatibilityRetryInterval)
+
+	return c.compatibility
+}
+
+// mediaType returns the media type of given subtype, with REST compatibility parameter when requested.
+func mediaType(subtype string, compatibility int) string {
+	if compatibility == 0 {
+		return "application/" + subtype
+	}
+
+	return fmt.Sprintf("application/vnd.elasticsearch+%s; compatible-with=%d", subtype, compatibility)
+}
@@ -0,0 +1,538 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newConfigMock returns config of the client connecting to given hosts without authentication.
+func newConfigMock(hosts ...string) *configMock {
+	return &configMock{
+		GetHostsFunc: func() []string {
+			return hosts
+		},
+		GetUsernameFunc: func() string {
+			return ""
+		},
+		GetPasswordFunc: func() string {
+			return ""
+		},
+		GetAPIKeyFunc: func() string {
+			return ""
+		},
+		GetServiceTokenFunc: func() string {
+			return ""
+		},
+		GetIndexFunc: func() string {
+			return "someIndexName"
+		},
+		GetCompressionFunc: func() string {
+			return internal.CompressionNone
+		},
+		GetTLSConfigFunc: func() *tls.Config {
+			return nil
+		},
+		GetProxyFunc: func() internal.ProxyFunc {
+			return nil
+		},
+		GetHeaderFunc: func() http.Header {
+			return nil
+		},
+		GetSignerFunc: func() *sigv4.Signer {
+			return nil
+		},
+	}
+}
+
+// newServer returns the server reporting given root endpoint response, which handles other requests with the handler.
+func newServer(t *testing.T, info string, handler http.HandlerFunc) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(info))
+
+			return
+		}
+
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("Fails when provided config object is invalid", func(t *testing.T) {
+		client, err := NewClient("invalid config object")
+
+		require.Nil(t, client)
+		require.EqualError(t, err, "provided config object is invalid")
+	})
+
+	t.Run("Fails when host has no scheme", func(t *testing.T) {
+		client, err := NewClient(newConfigMock("localhost"))
+
+		require.Nil(t, client)
+		require.EqualError(t, err, `cannot create client: url "localhost" must have scheme and host`)
+	})
+}
+
+func TestClient_Ping(t *testing.T) {
+	t.Run("Succeeds when OpenSearch responds", func(t *testing.T) {
+		server := newServer(t, `{"version":{"distribution":"opensearch","number":"2.11.0"}}`, http.NotFound)
+
+		client, err := NewClient(newConfigMock(server.URL))
+
+		require.NoError(t, err)
+		require.NoError(t, client.Ping(context.Background()))
+	})
+
+	t.Run("Fails when host responds with error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(newConfigMock(server.URL))
+
+		require.NoError(t, err)
+		require.EqualError(t, client.Ping(context.Background()), "host ping failed: 401 Unauthorized")
+	})
+}
+
+func TestClient_Bulk(t *testing.T) {
+	t.Run("Asks Elasticsearch 9 for REST API compatible with the previous major", func(t *testing.T) {
+		server := newServer(t, `{"version":{"number":"9.0.0"},"tagline":"You Know, for Search"}`, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/_bulk" ||
+				r.Header.Get("Content-Type") != "application/vnd.elasticsearch+x-ndjson; compatible-with=8" ||
+				r.Header.Get("Accept") != "application/vnd.elasticsearch+json; compatible-with=8" {
+				w.WriteHeader(http.StatusNotAcceptable)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+		})
+
+		client, err := NewClient(newConfigMock(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.NoError(t, err)
+		require.NoError(t, result.Close())
+	})
+
+	t.Run("Asks newer majors for REST API compatible with version 8", func(t *testing.T) {
+		server := newServer(t, `{"version":{"number":"10.1.0"}}`, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Accept") != "application/vnd.elasticsearch+json; compatible-with=8" {
+				w.WriteHeader(http.StatusNotAcceptable)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+		})
+
+		client, err := NewClient(newConfigMock(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.NoError(t, err)
+		require.NoError(t, result.Close())
+	})
+
+	t.Run("Does not request the version again after the server did not report it", func(t *testing.T) {
+		var (
+			infoRequests int32
+			bulkRequests int32
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/" {
+				atomic.AddInt32(&infoRequests, 1)
+				w.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+
+			atomic.AddInt32(&bulkRequests, 1)
+
+			if r.Header.Get("Accept") != "application/json" {
+				w.WriteHeader(http.StatusNotAcceptable)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClient(newConfigMock(server.URL))
+		require.NoError(t, err)
+
+		for n := 0; n < 5; n++ {
+			result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+			require.NoError(t, err)
+			require.NoError(t, result.Close())
+		}
+
+		require.Equal(t, int32(1), atomic.LoadInt32(&infoRequests))
+		require.Equal(t, int32(5), atomic.LoadInt32(&bulkRequests))
+	})
+
+	t.Run("Detects REST compatibility again once the server reports its version", func(t *testing.T) {
+		var (
+			infoRequests int32
+			accept       []string
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/" {
+				if atomic.AddInt32(&infoRequests, 1) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"version":{"number":"9.0.0"}}`))
+
+				return
+			}
+
+			accept = append(accept, r.Header.Get("Accept"))
+
+			_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClient(newConfigMock(server.URL))
+		require.NoError(t, err)
+
+		for n := 0; n < 3; n++ {
+			result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+			require.NoError(t, err)
+			require.NoError(t, result.Close())
+
+			// Pretend the retry interval has passed
+			client.compatibilityRetryAt = time.Time{}
+		}
+
+		require.Equal(t, int32(2), atomic.LoadInt32(&infoRequests))
+		require.Equal(t, []string{
+			"application/json",
+			"application/vnd.elasticsearch+json; compatible-with=8",
+			"application/vnd.elasticsearch+json; compatible-with=8",
+		}, accept)
+	})
+
+	t.Run("Sends plain media types to OpenSearch", func(t *testing.T) {
+		server := newServer(t, `{"version":{"distribution":"opensearch","number":"2.11.0"}}`, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Content-Type") != "application/x-ndjson" || r.Header.Get("Accept") != "application/json" {
+				w.WriteHeader(http.StatusNotAcceptable)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+		})
+
+		client, err := NewClient(newConfigMock(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.NoError(t, err)
+		require.NoError(t, result.Close())
+	})
+
+	t.Run("Authenticates with API key and configured headers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "ApiKey c2VjcmV0" || r.Header.Get("X-Gateway-Key") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+		}))
+		defer server.Close()
+
+		cfg := newConfigMock(server.URL)
+		cfg.GetUsernameFunc = func() string {
+			return "elastic"
+		}
+		cfg.GetAPIKeyFunc = func() string {
+			return "c2VjcmV0"
+		}
+		cfg.GetHeaderFunc = func() http.Header {
+			return http.Header{"X-Gateway-Key": []string{"secret"}}
+		}
+
+		client, err := NewClient(cfg)
+		require.NoError(t, err)
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.NoError(t, err)
+		require.NoError(t, result.Close())
+	})
+
+	t.Run("Sends trace ID as opaque ID", func(t *testing.T) {
+		traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+
+		server := newServer(t, `{"version":{"number":"8.2.0"}}`, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Opaque-Id") != "4bf92f3577b34da6a3ce929d0e0e4736" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+		})
+
+		client, err := NewClient(newConfigMock(server.URL))
+		require.NoError(t, err)
+
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		}))
+
+		result, err := client.Bulk(ctx, strings.NewReader("{}\n"))
+
+		require.NoError(t, err)
+		require.NoError(t, result.Close())
+	})
+
+	t.Run("Fails over to the next host when a host is unreachable", func(t *testing.T) {
+		var requests int32
+
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+
+		server := newServer(t, `{"version":{"number":"8.4.1"}}`, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+
+			_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+		})
+
+		client, err := NewClient(newConfigMock(unreachable.URL, server.URL))
+		require.NoError(t, err)
+
+		for i := 0; i < 4; i++ {
+			result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+			require.NoError(t, err)
+			require.NoError(t, result.Close())
+		}
+
+		require.Equal(t, int32(4), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Fails with transport error when no host is reachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		client, err := NewClient(newConfigMock(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.Nil(t, result)
+
+		var transportErr *internal.TransportError
+		require.ErrorAs(t, err, &transportErr)
+		require.True(t, internal.IsRetryableError(err))
+	})
+
+	t.Run("Fails with response error when Elasticsearch responds with error status", func(t *testing.T) {
+		server := newServer(t, `{"version":{"number":"9.0.0"}}`, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"type":"es_rejected_execution_exception","reason":"rejected execution"},"status":429}`))
+		})
+
+		client, err := NewClient(newConfigMock(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.Nil(t, result)
+		require.EqualError(t, err, "[es_rejected_execution_exception] rejected execution")
+
+		var responseErr *internal.ResponseError
+		require.ErrorAs(t, err, &responseErr)
+		require.Equal(t, http.StatusTooManyRequests, responseErr.StatusCode)
+		require.True(t, internal.IsRetryableError(err))
+	})
+
+	t.Run("Compresses request body and decompresses response when gzip compression is enabled", func(t *testing.T) {
+		server := newServer(t, `{"version":{"number":"9.0.0"}}`, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Content-Encoding") != "gzip" || r.Header.Get("Accept-Encoding") != "gzip" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			requestBody, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			if payload, err := io.ReadAll(requestBody); err != nil || string(payload) != "{}\n" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			w.Header().Set("Content-Encoding", "gzip")
+
+			responseBody := gzip.NewWriter(w)
+			_, _ = responseBody.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+			_ = responseBody.Close()
+		})
+
+		cfg := newConfigMock(server.URL)
+		cfg.GetCompressionFunc = func() string {
+			return internal.CompressionGzip
+		}
+
+		client, err := NewClient(cfg)
+		require.NoError(t, err)
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.NoError(t, err)
+
+		response, err := io.ReadAll(result)
+
+		require.NoError(t, err)
+		require.NoError(t, result.Close())
+		require.JSONEq(t, `{"took":1,"errors":false,"items":[]}`, string(response))
+	})
+}
+
+func TestClient_Search(t *testing.T) {
+	t.Run("Searches the configured index", func(t *testing.T) {
+		server := newServer(t, `{"version":{"number":"9.0.0"}}`, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/someIndexName/_search" {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"hits":{"total":{"value":0},"hits":[]}}`))
+		})
+
+		client, err := NewClient(newConfigMock(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.Search(context.Background(), strings.NewReader(`{"query":{"match_all":{}}}`))
+
+		require.NoError(t, err)
+
+		response, err := io.ReadAll(result)
+
+		require.NoError(t, err)
+		require.NoError(t, result.Close())
+		require.JSONEq(t, `{"hits":{"total":{"value":0},"hits":[]}}`, string(response))
+	})
+}
+
+func TestClient_GetIndexTemplate(t *testing.T) {
+	server := newServer(t, `{"version":{"number":"9.0.0"}}`, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_index_template/users" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"type":"resource_not_found_exception","reason":"index template matching [missing] not found"},"status":404}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"index_templates":[{"name":"users","index_template":{"index_patterns":["users-*"]}}]}`))
+	})
+
+	client, err := NewClient(newConfigMock(server.URL))
+	require.NoError(t, err)
+
+	t.Run("Returns installed template", func(t *testing.T) {
+		template, err := client.GetIndexTemplate(context.Background(), "users")
+
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"index_patterns": []interface{}{"users-*"}}, template)
+	})
+
+	t.Run("Returns nil when template does not exist", func(t *testing.T) {
+		template, err := client.GetIndexTemplate(context.Background(), "missing")
+
+		require.NoError(t, err)
+		require.Nil(t, template)
+	})
+}
+
+func TestClient_PrepareIndexOperation(t *testing.T) {
+	t.Run("Fails when payload could not be prepared", func(t *testing.T) {
+		client := Client{
+			cfg: newConfigMock(),
+		}
+
+		metadata, payload, err := client.PrepareIndexOperation("key", sdk.Record{
+			Payload: sdk.StructuredData{
+				"foo": complex64(1 + 2i),
+			},
+		}, 0)
+
+		require.Nil(t, metadata)
+		require.Nil(t, payload)
+		require.EqualError(t, err, "json: unsupported type: complex64")
+	})
+}
+
+func TestClient_PrepareDeleteOperation(t *testing.T) {
+	t.Run("Sets external version when provided", func(t *testing.T) {
+		client := Client{
+			cfg: newConfigMock(),
+		}
+
+		metadata, err := client.PrepareDeleteOperation("key", 1665000000000)
+
+		require.NoError(t, err)
+
+		metadataJSON, err := json.Marshal(metadata)
+
+		require.NoError(t, err)
+		require.JSONEq(t, `{"delete":{"_id":"key","_index":"someIndexName","version":1665000000000,"version_type":"external_gte"}}`, string(metadataJSON))
+	})
+}
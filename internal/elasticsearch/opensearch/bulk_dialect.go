@@ -0,0 +1,32 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opensearch
+
+import "github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/bulk"
+
+// See: https://opensearch.org/docs/latest/api-reference/document-apis/bulk/
+var bulkDialect = bulk.Dialect{
+	CreateAction: "create",
+	IndexAction:  "index",
+	UpdateAction: "update",
+	DeleteAction: "delete",
+	Upsert:       bulk.DocAsUpsert,
+	Params: bulk.Params{
+		Version:         "version",
+		VersionType:     "version_type",
+		RetryOnConflict: "retry_on_conflict",
+	},
+	RetryOnConflict: 3,
+}
@@ -0,0 +1,349 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/elastic/go-elasticsearch/v7/estransport"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/bulk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/response"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+)
+
+// NewClient creates the client of OpenSearch 1.x and 2.x, which accept the Elasticsearch 7.10 compatible API.
+// The client of go-elasticsearch is not used, as its product check rejects OpenSearch clusters.
+func NewClient(cfg interface{}) (*Client, error) {
+	configTyped, ok := cfg.(config)
+	if !ok {
+		return nil, errors.New("provided config object is invalid")
+	}
+
+	urls := make([]*url.URL, 0, len(configTyped.GetHosts()))
+	for _, host := range configTyped.GetHosts() {
+		u, err := url.Parse(strings.TrimRight(host, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create client: cannot parse url: %w", err)
+		}
+
+		urls = append(urls, u)
+	}
+
+	httpTransport := sigv4.Transport(
+		internal.NewTransport(configTyped.GetTLSConfig(), configTyped.GetProxy()),
+		configTyped.GetSigner(),
+	)
+
+	transport, err := estransport.New(estransport.Config{
+		URLs:                   urls,
+		Username:               configTyped.GetUsername(),
+		Password:               configTyped.GetPassword(),
+		ServiceToken:           configTyped.GetBearerToken(),
+		CertificateFingerprint: configTyped.GetCertificateFingerprint(),
+		Header:                 configTyped.GetHeader(),
+		DisableMetaHeader:      true,
+		Transport:              httpTransport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating transport: %w", err)
+	}
+
+	if configTyped.GetSniffOnStart() {
+		if err := transport.DiscoverNodes(); err != nil {
+			return nil, fmt.Errorf("cannot create client: failed to discover nodes: %w", err)
+		}
+	}
+
+	return &Client{
+		transport:     transport,
+		httpTransport: httpTransport,
+		es:            esapi.New(transport),
+		cfg:           configTyped,
+	}, nil
+}
+
+type Client struct {
+	transport     *estransport.Client
+	httpTransport http.RoundTripper
+	es            *esapi.API
+	cfg           config
+}
+
+// GetClient returns OpenSearch API client.
+func (c *Client) GetClient() *esapi.API {
+	return c.es
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	ping, err := c.es.Ping(c.es.Ping.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if ping.IsError() {
+		return fmt.Errorf("host ping failed: %s", ping.Status())
+	}
+
+	return nil
+}
+
+func (c *Client) Bulk(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
+	options := []func(*esapi.BulkRequest){
+		c.es.Bulk.WithContext(ctx),
+	}
+
+	if c.cfg.GetCompression() == internal.CompressionGzip {
+		compressed, err := internal.CompressRequestBody(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
+		}
+
+		reader = compressed
+		options = append(options, c.es.Bulk.WithHeader(internal.CompressionHeaders))
+	}
+
+	if opaqueID := internal.OpaqueID(ctx); opaqueID != "" {
+		options = append(options, c.es.Bulk.WithOpaqueID(opaqueID))
+	}
+
+	result, err := c.es.Bulk(reader, options...)
+	if err != nil {
+		c.sniffOnFailure(ctx)
+
+		return nil, &internal.TransportError{Err: err}
+	}
+
+	if result.Body, err = internal.DecompressResponseBody(result.Header, result.Body); err != nil {
+		return nil, &internal.TransportError{Err: err}
+	}
+
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	return result.Body, nil
+}
+
+// sniffOnFailure refreshes the list of nodes when enabled, after the request could not reach any of them.
+func (c *Client) sniffOnFailure(ctx context.Context) {
+	if !c.cfg.GetSniffOnFailure() {
+		return
+	}
+
+	if err := c.transport.DiscoverNodes(); err != nil {
+		sdk.Logger(ctx).Warn().Err(err).Msg("failed to discover nodes")
+	}
+}
+
+func (c *Client) IndexExists(ctx context.Context) (bool, error) {
+	result, err := c.es.Indices.Exists([]string{c.cfg.GetIndex()}, c.es.Indices.Exists.WithContext(ctx))
+	if err != nil {
+		return false, &internal.TransportError{Err: err}
+	}
+
+	switch result.StatusCode {
+	case http.StatusOK:
+		return true, result.Body.Close()
+
+	case http.StatusNotFound:
+		return false, result.Body.Close()
+
+	default:
+		return false, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+}
+
+func (c *Client) CreateIndex(ctx context.Context, body io.Reader) error {
+	result, err := c.es.Indices.Create(
+		c.cfg.GetIndex(),
+		c.es.Indices.Create.WithContext(ctx),
+		c.es.Indices.Create.WithBody(body),
+	)
+	if err != nil {
+		return &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	return result.Body.Close()
+}
+
+func (c *Client) GetMapping(ctx context.Context) (map[string]interface{}, error) {
+	result, err := c.es.Indices.GetMapping(
+		c.es.Indices.GetMapping.WithContext(ctx),
+		c.es.Indices.GetMapping.WithIndex(c.cfg.GetIndex()),
+	)
+	if err != nil {
+		return nil, &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	defer result.Body.Close()
+
+	var mappings map[string]struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if err := json.NewDecoder(result.Body).Decode(&mappings); err != nil {
+		return nil, fmt.Errorf("failed to decode the mapping: %w", err)
+	}
+
+	// The response is keyed by the concrete index name, which differs from the configured one for aliases
+	for _, index := range mappings {
+		return index.Mappings, nil
+	}
+
+	return nil, fmt.Errorf("mapping of %q index not found", c.cfg.GetIndex())
+}
+
+func (c *Client) GetIndexTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	result, err := c.es.Indices.GetIndexTemplate(
+		c.es.Indices.GetIndexTemplate.WithContext(ctx),
+		c.es.Indices.GetIndexTemplate.WithName(name),
+	)
+	if err != nil {
+		return nil, &internal.TransportError{Err: err}
+	}
+	if result.StatusCode == http.StatusNotFound {
+		return nil, result.Body.Close()
+	}
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	defer result.Body.Close()
+
+	var templates struct {
+		IndexTemplates []struct {
+			Name          string                 `json:"name"`
+			IndexTemplate map[string]interface{} `json:"index_template"`
+		} `json:"index_templates"`
+	}
+	if err := json.NewDecoder(result.Body).Decode(&templates); err != nil {
+		return nil, fmt.Errorf("failed to decode the index template: %w", err)
+	}
+
+	for _, template := range templates.IndexTemplates {
+		if template.Name == name {
+			return template.IndexTemplate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) PutIndexTemplate(ctx context.Context, name string, body io.Reader) error {
+	result, err := c.es.Indices.PutIndexTemplate(name, body, c.es.Indices.PutIndexTemplate.WithContext(ctx))
+	if err != nil {
+		return &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	return result.Body.Close()
+}
+
+func (c *Client) GetComponentTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	result, err := c.es.Cluster.GetComponentTemplate(
+		c.es.Cluster.GetComponentTemplate.WithContext(ctx),
+		c.es.Cluster.GetComponentTemplate.WithName(name),
+	)
+	if err != nil {
+		return nil, &internal.TransportError{Err: err}
+	}
+	if result.StatusCode == http.StatusNotFound {
+		return nil, result.Body.Close()
+	}
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	defer result.Body.Close()
+
+	var templates struct {
+		ComponentTemplates []struct {
+			Name              string                 `json:"name"`
+			ComponentTemplate map[string]interface{} `json:"component_template"`
+		} `json:"component_templates"`
+	}
+	if err := json.NewDecoder(result.Body).Decode(&templates); err != nil {
+		return nil, fmt.Errorf("failed to decode the component template: %w", err)
+	}
+
+	for _, template := range templates.ComponentTemplates {
+		if template.Name == name {
+			return template.ComponentTemplate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) PutComponentTemplate(ctx context.Context, name string, body io.Reader) error {
+	result, err := c.es.Cluster.PutComponentTemplate(name, body, c.es.Cluster.PutComponentTemplate.WithContext(ctx))
+	if err != nil {
+		return &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	return result.Body.Close()
+}
+
+func (c *Client) PutLifecyclePolicy(context.Context, string, io.Reader) error {
+	return errors.New("index lifecycle management is not supported by OpenSearch")
+}
+
+func (c *Client) CreateRolloverIndex(context.Context, io.Reader) error {
+	return errors.New("index lifecycle management is not supported by OpenSearch")
+}
+
+func (c *Client) CloseIdleConnections() {
+	internal.CloseIdleConnections(c.httpTransport)
+}
+
+func (c *Client) PrepareCreateOperation(item sdk.Record) (interface{}, interface{}, error) {
+	return c.bulkEncoder().CreateOperation(item)
+}
+
+func (c *Client) PrepareUpsertOperation(key string, item sdk.Record) (interface{}, interface{}, error) {
+	return c.bulkEncoder().UpsertOperation(key, item)
+}
+
+func (c *Client) PrepareIndexOperation(key string, item sdk.Record, version uint64) (interface{}, interface{}, error) {
+	return c.bulkEncoder().IndexOperation(key, item, version)
+}
+
+func (c *Client) PrepareDeleteOperation(key string, version uint64) (interface{}, error) {
+	return c.bulkEncoder().DeleteOperation(key, version)
+}
+
+// bulkEncoder creates Bulk API operations encoder for the configured index.
+func (c *Client) bulkEncoder() bulk.Encoder {
+	return bulk.NewEncoder(bulkDialect, c.cfg.GetIndex(), "")
+}
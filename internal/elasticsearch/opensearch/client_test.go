@@ -0,0 +1,382 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opensearch
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+	"github.com/stretchr/testify/require"
+)
+
+// openSearchInfo is the response of the root endpoint of OpenSearch, which fails the product check of go-elasticsearch.
+const openSearchInfo = `{"version":{"distribution":"opensearch","number":"2.11.0"},"tagline":"The OpenSearch Project: https://opensearch.org/"}`
+
+// newConfigMock returns config of the client connecting to given host without authentication.
+func newConfigMock(host string) *configMock {
+	return &configMock{
+		GetHostsFunc: func() []string {
+			return []string{host}
+		},
+		GetSniffOnStartFunc: func() bool {
+			return false
+		},
+		GetSniffOnFailureFunc: func() bool {
+			return false
+		},
+		GetUsernameFunc: func() string {
+			return ""
+		},
+		GetPasswordFunc: func() string {
+			return ""
+		},
+		GetBearerTokenFunc: func() string {
+			return ""
+		},
+		GetCertificateFingerprintFunc: func() string {
+			return ""
+		},
+		GetIndexFunc: func() string {
+			return "someIndexName"
+		},
+		GetCompressionFunc: func() string {
+			return internal.CompressionNone
+		},
+		GetTLSConfigFunc: func() *tls.Config {
+			return nil
+		},
+		GetProxyFunc: func() internal.ProxyFunc {
+			return nil
+		},
+		GetHeaderFunc: func() http.Header {
+			return nil
+		},
+		GetSignerFunc: func() *sigv4.Signer {
+			return nil
+		},
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("Fails when provided config object is invalid", func(t *testing.T) {
+		client, err := NewClient("invalid config object")
+
+		require.Nil(t, client)
+		require.EqualError(t, err, "provided config object is invalid")
+	})
+
+	t.Run("Connects to OpenSearch without the product check of Elasticsearch", func(t *testing.T) {
+		var requests int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+
+			if r.Header.Get("X-Elastic-Client-Meta") != "" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			switch r.URL.Path {
+			case "/":
+				_, _ = w.Write([]byte(openSearchInfo))
+
+			case "/_bulk":
+				_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClient(newConfigMock(server.URL))
+
+		require.NoError(t, err)
+		require.NoError(t, client.Ping(context.Background()))
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.NoError(t, err)
+		require.NoError(t, result.Close())
+		require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Authenticates with configured username and password", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(openSearchInfo))
+		}))
+		defer server.Close()
+
+		cfg := newConfigMock(server.URL)
+		cfg.GetUsernameFunc = func() string {
+			return "admin"
+		}
+		cfg.GetPasswordFunc = func() string {
+			return "secret"
+		}
+
+		client, err := NewClient(cfg)
+
+		require.NoError(t, err)
+		require.NoError(t, client.Ping(context.Background()))
+	})
+
+	t.Run("Authenticates with configured bearer token", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer eyJhbGciOiJIUzI1NiJ9" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(openSearchInfo))
+		}))
+		defer server.Close()
+
+		cfg := newConfigMock(server.URL)
+		cfg.GetBearerTokenFunc = func() string {
+			return "eyJhbGciOiJIUzI1NiJ9"
+		}
+
+		client, err := NewClient(cfg)
+
+		require.NoError(t, err)
+		require.NoError(t, client.Ping(context.Background()))
+	})
+
+	t.Run("Fails when nodes could not be discovered on start", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		cfg := newConfigMock(server.URL)
+		cfg.GetSniffOnStartFunc = func() bool {
+			return true
+		}
+
+		client, err := NewClient(cfg)
+
+		require.Nil(t, client)
+		require.ErrorContains(t, err, "cannot create client: failed to discover nodes")
+	})
+
+	t.Run("Signs requests with AWS Signature Version 4 when signer is configured", func(t *testing.T) {
+		var signedRequests int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") ||
+				!strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/aoss/aws4_request") {
+				w.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+
+			atomic.AddInt32(&signedRequests, 1)
+
+			_, _ = w.Write([]byte(openSearchInfo))
+		}))
+		defer server.Close()
+
+		cfg := newConfigMock(server.URL)
+		cfg.GetSignerFunc = func() *sigv4.Signer {
+			return &sigv4.Signer{
+				Region:  "eu-west-1",
+				Service: "aoss",
+				Credentials: sigv4.StaticCredentials{
+					AccessKeyID:     "AKIDEXAMPLE",
+					SecretAccessKey: "secret",
+				},
+			}
+		}
+
+		client, err := NewClient(cfg)
+
+		require.NoError(t, err)
+		require.NoError(t, client.Ping(context.Background()))
+		require.NotZero(t, atomic.LoadInt32(&signedRequests))
+	})
+}
+
+func TestClient_Bulk(t *testing.T) {
+	t.Run("Fails with response error when OpenSearch responds with error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"type":"rejected_execution_exception","reason":"rejected execution"},"status":429}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClient(newConfigMock(server.URL))
+		require.NoError(t, err)
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.Nil(t, result)
+		require.EqualError(t, err, "[rejected_execution_exception] rejected execution")
+
+		var responseErr *internal.ResponseError
+		require.ErrorAs(t, err, &responseErr)
+		require.Equal(t, http.StatusTooManyRequests, responseErr.StatusCode)
+		require.True(t, internal.IsRetryableError(err))
+	})
+
+	t.Run("Compresses request body and decompresses response when gzip compression is enabled", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Content-Encoding") != "gzip" || r.Header.Get("Accept-Encoding") != "gzip" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			requestBody, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			if payload, err := io.ReadAll(requestBody); err != nil || string(payload) != "{}\n" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			w.Header().Set("Content-Encoding", "gzip")
+
+			responseBody := gzip.NewWriter(w)
+			_, _ = responseBody.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+			_ = responseBody.Close()
+		}))
+		defer server.Close()
+
+		cfg := newConfigMock(server.URL)
+		cfg.GetCompressionFunc = func() string {
+			return internal.CompressionGzip
+		}
+
+		client, err := NewClient(cfg)
+		require.NoError(t, err)
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.NoError(t, err)
+
+		response, err := io.ReadAll(result)
+
+		require.NoError(t, err)
+		require.NoError(t, result.Close())
+		require.JSONEq(t, `{"took":1,"errors":false,"items":[]}`, string(response))
+	})
+
+	t.Run("Discovers nodes when OpenSearch is unreachable and sniffing on failure is enabled", func(t *testing.T) {
+		var nodesDiscovered int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/_nodes/http":
+				atomic.AddInt32(&nodesDiscovered, 1)
+
+				_, _ = w.Write([]byte(`{"nodes":{}}`))
+
+			default:
+				// Drop the connection to fail the request
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err == nil {
+					_ = conn.Close()
+				}
+			}
+		}))
+		defer server.Close()
+
+		cfg := newConfigMock(server.URL)
+		cfg.GetSniffOnFailureFunc = func() bool {
+			return true
+		}
+
+		client, err := NewClient(cfg)
+		require.NoError(t, err)
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.Nil(t, result)
+
+		var transportErr *internal.TransportError
+		require.ErrorAs(t, err, &transportErr)
+		require.Equal(t, int32(1), atomic.LoadInt32(&nodesDiscovered))
+	})
+}
+
+func TestClient_PutLifecyclePolicy(t *testing.T) {
+	t.Run("Fails as index lifecycle management is not supported", func(t *testing.T) {
+		client := Client{}
+
+		err := client.PutLifecyclePolicy(context.Background(), "policy", strings.NewReader("{}"))
+
+		require.EqualError(t, err, "index lifecycle management is not supported by OpenSearch")
+	})
+}
+
+func TestClient_PrepareIndexOperation(t *testing.T) {
+	t.Run("Fails when payload could not be prepared", func(t *testing.T) {
+		client := Client{
+			cfg: newConfigMock(""),
+		}
+
+		metadata, payload, err := client.PrepareIndexOperation("key", sdk.Record{
+			Payload: sdk.StructuredData{
+				"foo": complex64(1 + 2i),
+			},
+		}, 0)
+
+		require.Nil(t, metadata)
+		require.Nil(t, payload)
+		require.EqualError(t, err, "json: unsupported type: complex64")
+	})
+}
+
+func TestClient_PrepareDeleteOperation(t *testing.T) {
+	t.Run("Sets external version when provided", func(t *testing.T) {
+		client := Client{
+			cfg: newConfigMock(""),
+		}
+
+		metadata, err := client.PrepareDeleteOperation("key", 1665000000000)
+
+		require.NoError(t, err)
+
+		metadataJSON, err := json.Marshal(metadata)
+
+		require.NoError(t, err)
+		require.JSONEq(t, `{"delete":{"_id":"key","_index":"someIndexName","version":1665000000000,"version_type":"external_gte"}}`, string(metadataJSON))
+	})
+}
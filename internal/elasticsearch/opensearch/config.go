@@ -0,0 +1,40 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opensearch
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+)
+
+//go:generate moq -out config_moq_test.go . config
+type config interface {
+	GetHosts() []string
+	GetSniffOnStart() bool
+	GetSniffOnFailure() bool
+	GetUsername() string
+	GetPassword() string
+	GetBearerToken() string
+	GetCertificateFingerprint() string
+	GetIndex() string
+	GetCompression() string
+	GetTLSConfig() *tls.Config
+	GetProxy() internal.ProxyFunc
+	GetHeader() http.Header
+	GetSigner() *sigv4.Signer
+}
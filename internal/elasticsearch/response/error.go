@@ -0,0 +1,59 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+)
+
+// errorResponse is the body of failed responses of the REST API.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/common-options.html
+type errorResponse struct {
+	Error struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
+// ParseError reads and closes the body of the failed response and returns it as an error.
+// The status, e.g. "400 Bad Request", is used as the reason when the body does not describe the error.
+func ParseError(statusCode int, status string, body io.ReadCloser) error {
+	bodyContents, err := io.ReadAll(body)
+	if err != nil {
+		return &internal.TransportError{Err: fmt.Errorf("failed to read the result: %w", err)}
+	}
+
+	if err := body.Close(); err != nil {
+		return &internal.TransportError{Err: fmt.Errorf("failed to read the result: %w", err)}
+	}
+
+	var errorDetails errorResponse
+	if err := json.Unmarshal(bodyContents, &errorDetails); err != nil || errorDetails.Error.Type == "" {
+		return &internal.ResponseError{
+			StatusCode: statusCode,
+			Reason:     status,
+		}
+	}
+
+	return &internal.ResponseError{
+		StatusCode: statusCode,
+		Type:       errorDetails.Error.Type,
+		Reason:     errorDetails.Error.Reason,
+	}
+}
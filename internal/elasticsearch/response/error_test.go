@@ -0,0 +1,63 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseError(t *testing.T) {
+	t.Run("Returns type and reason of the error", func(t *testing.T) {
+		err := ParseError(400, "400 Bad Request", io.NopCloser(strings.NewReader(
+			`{"error":{"type":"illegal_argument_exception","reason":"invalid request"},"status":400}`,
+		)))
+
+		require.Equal(t, &internal.ResponseError{
+			StatusCode: 400,
+			Type:       "illegal_argument_exception",
+			Reason:     "invalid request",
+		}, err)
+	})
+
+	t.Run("Returns status when the body does not describe the error", func(t *testing.T) {
+		err := ParseError(502, "502 Bad Gateway", io.NopCloser(strings.NewReader("<html>Bad Gateway</html>")))
+
+		require.Equal(t, &internal.ResponseError{
+			StatusCode: 502,
+			Reason:     "502 Bad Gateway",
+		}, err)
+	})
+
+	t.Run("Fails when the body could not be read", func(t *testing.T) {
+		err := ParseError(500, "500 Internal Server Error", io.NopCloser(failingReader{}))
+
+		var transportErr *internal.TransportError
+
+		require.ErrorAs(t, err, &transportErr)
+		require.EqualError(t, transportErr.Err, "failed to read the result: connection reset")
+	})
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
@@ -14,16 +14,19 @@
 
 package v5
 
-import "encoding/json"
+import "github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/bulk"
 
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/5.6/docs-bulk.html
-type bulkRequestCreateSource []byte
-
-func (b bulkRequestCreateSource) MarshalJSON() ([]byte, error) {
-	return b, nil
-}
-
-type bulkRequestUpdateSource struct {
-	Doc         json.RawMessage `json:"doc"`
-	DocAsUpsert bool            `json:"doc_as_upsert"`
+var bulkDialect = bulk.Dialect{
+	CreateAction: "index",
+	IndexAction:  "index",
+	UpdateAction: "update",
+	DeleteAction: "delete",
+	DocumentType: true,
+	Upsert:       bulk.DocAsUpsert,
+	Params: bulk.Params{
+		Version:         "_version",
+		VersionType:     "_version_type",
+		RetryOnConflict: "_retry_on_conflict",
+	},
 }
@@ -20,9 +20,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/elastic/go-elasticsearch/v5"
+	"github.com/elastic/go-elasticsearch/v5/esapi"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/bulk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/response"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
 )
 
 func NewClient(cfg interface{}) (*Client, error) {
@@ -31,24 +37,35 @@ func NewClient(cfg interface{}) (*Client, error) {
 		return nil, errors.New("provided config object is invalid")
 	}
 
+	transport := sigv4.Transport(
+		internal.WithHeader(
+			internal.NewTransport(configTyped.GetTLSConfig(), configTyped.GetProxy()),
+			configTyped.GetHeader(),
+		),
+		configTyped.GetSigner(),
+	)
+
 	esClient, err := elasticsearch.NewClient(elasticsearch.Config{
-		Addresses: []string{configTyped.GetHost()},
+		Addresses: configTyped.GetHosts(),
 		Username:  configTyped.GetUsername(),
 		Password:  configTyped.GetPassword(),
+		Transport: transport,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &Client{
-		es:  esClient,
-		cfg: configTyped,
+		es:        esClient,
+		transport: transport,
+		cfg:       configTyped,
 	}, nil
 }
 
 type Client struct {
-	es  *elasticsearch.Client
-	cfg config
+	es        *elasticsearch.Client
+	transport http.RoundTripper
+	cfg       config
 }
 
 // GetClient returns Elasticsearch v5 client.
@@ -69,93 +86,183 @@ func (c *Client) Ping(ctx context.Context) error {
 }
 
 func (c *Client) Bulk(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
-	result, err := c.es.Bulk(reader, c.es.Bulk.WithContext(ctx))
-	if err != nil {
-		return nil, err
+	options := []func(*esapi.BulkRequest){
+		c.es.Bulk.WithContext(ctx),
 	}
-	if result.IsError() {
-		bodyContents, err := io.ReadAll(result.Body)
+
+	if c.cfg.GetCompression() == internal.CompressionGzip {
+		compressed, err := internal.CompressRequestBody(reader)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read the result: %w", err)
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
 		}
 
-		if err := result.Body.Close(); err != nil {
-			return nil, fmt.Errorf("failed to read the result: %w", err)
-		}
+		reader = compressed
+		options = append(options, c.es.Bulk.WithHeader(internal.CompressionHeaders))
+	}
 
-		var errorDetails ErrorResponse
-		if err := json.Unmarshal(bodyContents, &errorDetails); err != nil {
-			return nil, errors.New(result.Status())
-		}
+	if opaqueID := internal.OpaqueID(ctx); opaqueID != "" {
+		options = append(options, c.es.Bulk.WithHeader(map[string]string{internal.OpaqueIDHeader: opaqueID}))
+	}
 
-		return nil, fmt.Errorf("[%s] %s", errorDetails.Error.Type, errorDetails.Error.Reason)
+	result, err := c.es.Bulk(reader, options...)
+	if err != nil {
+		return nil, &internal.TransportError{Err: err}
+	}
+
+	if result.Body, err = internal.DecompressResponseBody(result.Header, result.Body); err != nil {
+		return nil, &internal.TransportError{Err: err}
+	}
+
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
 	}
 
 	return result.Body, nil
 }
 
-func (c *Client) PrepareCreateOperation(item sdk.Record) (interface{}, interface{}, error) {
-	// Prepare metadata
-	metadata := bulkRequestActionAndMetadata{
-		Index: &bulkRequestIndexAction{
-			Index: c.cfg.GetIndex(),
-			Type:  c.cfg.GetType(),
-		},
+func (c *Client) IndexExists(ctx context.Context) (bool, error) {
+	result, err := c.es.Indices.Exists([]string{c.cfg.GetIndex()}, c.es.Indices.Exists.WithContext(ctx))
+	if err != nil {
+		return false, &internal.TransportError{Err: err}
 	}
 
-	// Prepare payload
-	payload, err := preparePayload(&item)
+	switch result.StatusCode {
+	case http.StatusOK:
+		return true, result.Body.Close()
+
+	case http.StatusNotFound:
+		return false, result.Body.Close()
+
+	default:
+		return false, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+}
+
+func (c *Client) CreateIndex(ctx context.Context, body io.Reader) error {
+	result, err := c.es.Indices.Create(
+		c.cfg.GetIndex(),
+		c.es.Indices.Create.WithContext(ctx),
+		c.es.Indices.Create.WithBody(body),
+	)
 	if err != nil {
-		return nil, nil, err
+		return &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return response.ParseError(result.StatusCode, result.Status(), result.Body)
 	}
 
-	return metadata, bulkRequestCreateSource(payload), nil
+	return result.Body.Close()
 }
 
-func (c *Client) PrepareUpsertOperation(key string, item sdk.Record) (interface{}, interface{}, error) {
-	// Prepare metadata
-	metadata := bulkRequestActionAndMetadata{
-		Update: &bulkRequestUpdateAction{
-			ID:    key,
-			Index: c.cfg.GetIndex(),
-			Type:  c.cfg.GetType(),
-		},
+func (c *Client) GetMapping(ctx context.Context) (map[string]interface{}, error) {
+	result, err := c.es.Indices.GetMapping(
+		c.es.Indices.GetMapping.WithContext(ctx),
+		c.es.Indices.GetMapping.WithIndex(c.cfg.GetIndex()),
+		c.es.Indices.GetMapping.WithDocumentType(c.cfg.GetType()),
+	)
+	if err != nil {
+		return nil, &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	defer result.Body.Close()
+
+	var mappings map[string]struct {
+		Mappings map[string]map[string]interface{} `json:"mappings"`
+	}
+	if err := json.NewDecoder(result.Body).Decode(&mappings); err != nil {
+		return nil, fmt.Errorf("failed to decode the mapping: %w", err)
 	}
 
-	// Prepare payload
-	var err error
+	// The response is keyed by the concrete index name, which differs from the configured one for aliases
+	for _, index := range mappings {
+		mapping, ok := index.Mappings[c.cfg.GetType()]
+		if !ok {
+			return nil, fmt.Errorf("mapping of %q type not found", c.cfg.GetType())
+		}
 
-	payload := bulkRequestUpdateSource{
-		Doc:         nil,
-		DocAsUpsert: true,
+		return mapping, nil
 	}
 
-	payload.Doc, err = preparePayload(&item)
+	return nil, fmt.Errorf("mapping of %q index not found", c.cfg.GetIndex())
+}
+
+func (c *Client) GetIndexTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	result, err := c.es.Indices.GetTemplate(
+		c.es.Indices.GetTemplate.WithContext(ctx),
+		c.es.Indices.GetTemplate.WithName(name),
+	)
 	if err != nil {
-		return nil, nil, err
+		return nil, &internal.TransportError{Err: err}
+	}
+	if result.StatusCode == http.StatusNotFound {
+		return nil, result.Body.Close()
 	}
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	defer result.Body.Close()
 
-	return metadata, payload, nil
+	var templates map[string]map[string]interface{}
+	if err := json.NewDecoder(result.Body).Decode(&templates); err != nil {
+		return nil, fmt.Errorf("failed to decode the index template: %w", err)
+	}
+
+	return templates[name], nil
 }
 
-func (c *Client) PrepareDeleteOperation(key string) (interface{}, error) {
-	return bulkRequestActionAndMetadata{
-		Delete: &bulkRequestDeleteAction{
-			ID:    key,
-			Index: c.cfg.GetIndex(),
-			Type:  c.cfg.GetType(),
-		},
-	}, nil
+func (c *Client) PutIndexTemplate(ctx context.Context, name string, body io.Reader) error {
+	result, err := c.es.Indices.PutTemplate(name, body, c.es.Indices.PutTemplate.WithContext(ctx))
+	if err != nil {
+		return &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	return result.Body.Close()
 }
 
-// preparePayload encodes Record's payload as JSON.
-func preparePayload(item *sdk.Record) (json.RawMessage, error) {
-	switch itemPayload := item.Payload.(type) {
-	case sdk.StructuredData:
-		return json.Marshal(itemPayload)
+func (c *Client) GetComponentTemplate(context.Context, string) (map[string]interface{}, error) {
+	return nil, errors.New("component templates are not supported")
+}
 
-	default:
-		// Nothing more can be done, we can trust the source to provide valid JSON
-		return itemPayload.Bytes(), nil
-	}
+func (c *Client) PutComponentTemplate(context.Context, string, io.Reader) error {
+	return errors.New("component templates are not supported")
+}
+
+func (c *Client) PutLifecyclePolicy(context.Context, string, io.Reader) error {
+	return errors.New("index lifecycle management is not supported")
+}
+
+func (c *Client) CreateRolloverIndex(context.Context, io.Reader) error {
+	return errors.New("index lifecycle management is not supported")
+}
+
+func (c *Client) CloseIdleConnections() {
+	internal.CloseIdleConnections(c.transport)
+}
+
+func (c *Client) PrepareCreateOperation(item sdk.Record) (interface{}, interface{}, error) {
+	return c.bulkEncoder().CreateOperation(item)
+}
+
+func (c *Client) PrepareUpsertOperation(key string, item sdk.Record) (interface{}, interface{}, error) {
+	return c.bulkEncoder().UpsertOperation(key, item)
+}
+
+func (c *Client) PrepareIndexOperation(key string, item sdk.Record, version uint64) (interface{}, interface{}, error) {
+	return c.bulkEncoder().IndexOperation(key, item, version)
+}
+
+func (c *Client) PrepareDeleteOperation(key string, version uint64) (interface{}, error) {
+	return c.bulkEncoder().DeleteOperation(key, version)
+}
+
+// bulkEncoder creates Bulk API operations encoder for the configured index.
+func (c *Client) bulkEncoder() bulk.Encoder {
+	return bulk.NewEncoder(bulkDialect, c.cfg.GetIndex(), c.cfg.GetType())
 }
@@ -15,10 +15,23 @@
 package v5
 
 import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/elastic/go-elasticsearch/v5"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,6 +42,148 @@ func TestNewClient(t *testing.T) {
 		require.Nil(t, client)
 		require.EqualError(t, err, "provided config object is invalid")
 	})
+
+	t.Run("Connects to Elasticsearch over TLS with configured certificate authorities", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+			_, _ = w.Write([]byte(`{"version":{"number":"5.0.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+		}))
+		defer server.Close()
+
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(server.Certificate())
+
+		client, err := NewClient(&configMock{
+			GetHostsFunc: func() []string {
+				return []string{server.URL}
+			},
+			GetUsernameFunc: func() string {
+				return ""
+			},
+			GetPasswordFunc: func() string {
+				return ""
+			},
+			GetTLSConfigFunc: func() *tls.Config {
+				return &tls.Config{RootCAs: rootCAs}
+			},
+			GetProxyFunc: func() internal.ProxyFunc {
+				return nil
+			},
+			GetHeaderFunc: func() http.Header {
+				return nil
+			},
+			GetSignerFunc: func() *sigv4.Signer {
+				return nil
+			},
+		})
+
+		require.NoError(t, err)
+		require.NoError(t, client.Ping(context.Background()))
+	})
+	t.Run("Sends requests through configured proxy with configured headers", func(t *testing.T) {
+		var proxiedRequests int32
+
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Host != "elasticsearch.invalid:9200" || r.Header.Get("X-Gateway-Key") != "secret" {
+				w.WriteHeader(http.StatusBadGateway)
+
+				return
+			}
+
+			atomic.AddInt32(&proxiedRequests, 1)
+
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+			_, _ = w.Write([]byte(`{"version":{"number":"5.0.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+		}))
+		defer proxy.Close()
+
+		proxyURL, err := url.Parse(proxy.URL)
+		require.NoError(t, err)
+
+		client, err := NewClient(&configMock{
+			GetHostsFunc: func() []string {
+				return []string{"http://elasticsearch.invalid:9200"}
+			},
+			GetUsernameFunc: func() string {
+				return ""
+			},
+			GetPasswordFunc: func() string {
+				return ""
+			},
+			GetTLSConfigFunc: func() *tls.Config {
+				return nil
+			},
+			GetProxyFunc: func() internal.ProxyFunc {
+				return http.ProxyURL(proxyURL)
+			},
+			GetHeaderFunc: func() http.Header {
+				return http.Header{"X-Gateway-Key": []string{"secret"}}
+			},
+			GetSignerFunc: func() *sigv4.Signer {
+				return nil
+			},
+		})
+
+		require.NoError(t, err)
+		require.NoError(t, client.Ping(context.Background()))
+		require.NotZero(t, atomic.LoadInt32(&proxiedRequests))
+	})
+	t.Run("Signs requests with AWS Signature Version 4 when signer is configured", func(t *testing.T) {
+		var signedRequests int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+				w.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+
+			atomic.AddInt32(&signedRequests, 1)
+
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+			_, _ = w.Write([]byte(`{"version":{"number":"5.0.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClient(&configMock{
+			GetHostsFunc: func() []string {
+				return []string{server.URL}
+			},
+			GetUsernameFunc: func() string {
+				return ""
+			},
+			GetPasswordFunc: func() string {
+				return ""
+			},
+			GetTLSConfigFunc: func() *tls.Config {
+				return nil
+			},
+			GetProxyFunc: func() internal.ProxyFunc {
+				return nil
+			},
+			GetHeaderFunc: func() http.Header {
+				return nil
+			},
+			GetSignerFunc: func() *sigv4.Signer {
+				return &sigv4.Signer{
+					Region:  "eu-west-1",
+					Service: "es",
+					Credentials: sigv4.StaticCredentials{
+						AccessKeyID:     "AKIDEXAMPLE",
+						SecretAccessKey: "secret",
+					},
+				}
+			},
+		})
+
+		require.NoError(t, err)
+		require.NoError(t, client.Ping(context.Background()))
+		require.NotZero(t, atomic.LoadInt32(&signedRequests))
+	})
+
 }
 
 func TestClient_GetClient(t *testing.T) {
@@ -41,6 +196,289 @@ func TestClient_GetClient(t *testing.T) {
 	require.Same(t, esClient, client.GetClient())
 }
 
+func TestClient_Bulk(t *testing.T) {
+	t.Run("Fails with response error when Elasticsearch responds with error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+			if r.URL.Path == "/" {
+				_, _ = w.Write([]byte(`{"version":{"number":"5.0.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+
+				return
+			}
+
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"type":"es_rejected_execution_exception","reason":"rejected execution"},"status":429}`))
+		}))
+		defer server.Close()
+
+		esClient, err := elasticsearch.NewClient(elasticsearch.Config{
+			Addresses: []string{server.URL},
+		})
+		require.NoError(t, err)
+
+		client := Client{
+			es: esClient,
+			cfg: &configMock{
+				GetCompressionFunc: func() string {
+					return internal.CompressionNone
+				},
+			},
+		}
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.Nil(t, result)
+		require.EqualError(t, err, "[es_rejected_execution_exception] rejected execution")
+
+		var responseErr *internal.ResponseError
+		require.ErrorAs(t, err, &responseErr)
+		require.Equal(t, http.StatusTooManyRequests, responseErr.StatusCode)
+		require.True(t, internal.IsRetryableError(err))
+	})
+
+	t.Run("Fails with transport error when Elasticsearch is unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		esClient, err := elasticsearch.NewClient(elasticsearch.Config{
+			Addresses: []string{server.URL},
+		})
+		require.NoError(t, err)
+
+		client := Client{
+			es: esClient,
+			cfg: &configMock{
+				GetCompressionFunc: func() string {
+					return internal.CompressionNone
+				},
+			},
+		}
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.Nil(t, result)
+
+		var transportErr *internal.TransportError
+		require.ErrorAs(t, err, &transportErr)
+		require.True(t, internal.IsRetryableError(err))
+	})
+
+	t.Run("Compresses request body and decompresses response when gzip compression is enabled", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+			if r.URL.Path == "/" {
+				_, _ = w.Write([]byte(`{"version":{"number":"5.0.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+
+				return
+			}
+
+			if r.Header.Get("Content-Encoding") != "gzip" || r.Header.Get("Accept-Encoding") != "gzip" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			requestBody, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			if payload, err := io.ReadAll(requestBody); err != nil || string(payload) != "{}\n" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			w.Header().Set("Content-Encoding", "gzip")
+
+			responseBody := gzip.NewWriter(w)
+			_, _ = responseBody.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+			_ = responseBody.Close()
+		}))
+		defer server.Close()
+
+		esClient, err := elasticsearch.NewClient(elasticsearch.Config{
+			Addresses: []string{server.URL},
+		})
+		require.NoError(t, err)
+
+		client := Client{
+			es: esClient,
+			cfg: &configMock{
+				GetCompressionFunc: func() string {
+					return internal.CompressionGzip
+				},
+			},
+		}
+
+		result, err := client.Bulk(context.Background(), strings.NewReader("{}\n"))
+
+		require.NoError(t, err)
+
+		response, err := io.ReadAll(result)
+
+		require.NoError(t, err)
+		require.NoError(t, result.Close())
+		require.JSONEq(t, `{"took":1,"errors":false,"items":[]}`, string(response))
+	})
+}
+
+func TestClient_IndexExists(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		statusCode int
+		exists     bool
+	}{
+		{name: "Returns true when index exists", statusCode: http.StatusOK, exists: true},
+		{name: "Returns false when index does not exist", statusCode: http.StatusNotFound, exists: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+				if r.URL.Path == "/" {
+					_, _ = w.Write([]byte(`{"version":{"number":"5.6.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+
+					return
+				}
+
+				if r.Method != http.MethodHead || r.URL.Path != "/users" {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			esClient, err := elasticsearch.NewClient(elasticsearch.Config{
+				Addresses: []string{server.URL},
+			})
+			require.NoError(t, err)
+
+			client := Client{
+				es: esClient,
+				cfg: &configMock{
+					GetIndexFunc: func() string {
+						return "users"
+					},
+					GetTypeFunc: func() string {
+						return "user"
+					},
+				},
+			}
+
+			exists, err := client.IndexExists(context.Background())
+
+			require.NoError(t, err)
+			require.Equal(t, tt.exists, exists)
+		})
+	}
+}
+
+func TestClient_GetMapping(t *testing.T) {
+	t.Run("Returns mapping of the index behind the alias", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+			if r.URL.Path == "/" {
+				_, _ = w.Write([]byte(`{"version":{"number":"5.6.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"users-000001":{"mappings":{"user":{"properties":{"name":{"type":"keyword"}}}}}}`))
+		}))
+		defer server.Close()
+
+		esClient, err := elasticsearch.NewClient(elasticsearch.Config{
+			Addresses: []string{server.URL},
+		})
+		require.NoError(t, err)
+
+		client := Client{
+			es: esClient,
+			cfg: &configMock{
+				GetIndexFunc: func() string {
+					return "users"
+				},
+				GetTypeFunc: func() string {
+					return "user"
+				},
+			},
+		}
+
+		mapping, err := client.GetMapping(context.Background())
+
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{"type": "keyword"},
+			},
+		}, mapping)
+	})
+}
+
+func TestClient_GetIndexTemplate(t *testing.T) {
+	newClient := func(t *testing.T, statusCode int, body string) *Client {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+			if r.URL.Path == "/" {
+				_, _ = w.Write([]byte(`{"version":{"number":"5.6.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+
+				return
+			}
+
+			if r.URL.Path != "/_template/users" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(server.Close)
+
+		esClient, err := elasticsearch.NewClient(elasticsearch.Config{
+			Addresses: []string{server.URL},
+		})
+		require.NoError(t, err)
+
+		return &Client{
+			es: esClient,
+		}
+	}
+
+	t.Run("Returns installed template", func(t *testing.T) {
+		client := newClient(t, http.StatusOK, `{"users":{"order":0,"version":2,"index_patterns":["users-*"]}}`)
+
+		template, err := client.GetIndexTemplate(context.Background(), "users")
+
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"order":          float64(0),
+			"version":        float64(2),
+			"index_patterns": []interface{}{"users-*"},
+		}, template)
+	})
+
+	t.Run("Returns nil when template does not exist", func(t *testing.T) {
+		client := newClient(t, http.StatusNotFound, `{}`)
+
+		template, err := client.GetIndexTemplate(context.Background(), "users")
+
+		require.NoError(t, err)
+		require.Nil(t, template)
+	})
+}
+
 func TestClient_PrepareCreateOperation(t *testing.T) {
 	t.Run("Fails when payload could not be prepared", func(t *testing.T) {
 		client := Client{
@@ -90,3 +528,52 @@ func TestClient_PrepareUpsertOperation(t *testing.T) {
 		require.EqualError(t, err, "json: unsupported type: complex64")
 	})
 }
+
+func TestClient_PrepareIndexOperation(t *testing.T) {
+	t.Run("Fails when payload could not be prepared", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetIndexFunc: func() string {
+					return "someIndexName"
+				},
+				GetTypeFunc: func() string {
+					return "someTypeName"
+				},
+			},
+		}
+
+		metadata, payload, err := client.PrepareIndexOperation("key", sdk.Record{
+			Payload: sdk.StructuredData{
+				"foo": complex64(1 + 2i),
+			},
+		}, 0)
+
+		require.Nil(t, metadata)
+		require.Nil(t, payload)
+		require.EqualError(t, err, "json: unsupported type: complex64")
+	})
+}
+
+func TestClient_PrepareDeleteOperation(t *testing.T) {
+	t.Run("Sets external version when provided", func(t *testing.T) {
+		client := Client{
+			cfg: &configMock{
+				GetIndexFunc: func() string {
+					return "someIndexName"
+				},
+				GetTypeFunc: func() string {
+					return "someTypeName"
+				},
+			},
+		}
+
+		metadata, err := client.PrepareDeleteOperation("key", 1665000000000)
+
+		require.NoError(t, err)
+
+		metadataJSON, err := json.Marshal(metadata)
+
+		require.NoError(t, err)
+		require.JSONEq(t, `{"delete":{"_id":"key","_index":"someIndexName","_type":"someTypeName","_version":1665000000000,"_version_type":"external_gte"}}`, string(metadataJSON))
+	})
+}
@@ -14,11 +14,24 @@
 
 package v5
 
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+)
+
 //go:generate moq -out config_moq_test.go . config
 type config interface {
-	GetHost() string
+	GetHosts() []string
 	GetUsername() string
 	GetPassword() string
 	GetIndex() string
+	GetCompression() string
+	GetTLSConfig() *tls.Config
+	GetProxy() internal.ProxyFunc
+	GetHeader() http.Header
+	GetSigner() *sigv4.Signer
 	GetType() string
 }
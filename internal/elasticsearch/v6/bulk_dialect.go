@@ -14,16 +14,20 @@
 
 package v6
 
-import "encoding/json"
+import "github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/bulk"
 
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/6.8/docs-bulk.html
-type bulkRequestCreateSource []byte
-
-func (b bulkRequestCreateSource) MarshalJSON() ([]byte, error) {
-	return b, nil
-}
-
-type bulkRequestOptionalSource struct {
-	Doc         json.RawMessage `json:"doc"`
-	DocAsUpsert bool            `json:"doc_as_upsert"`
+var bulkDialect = bulk.Dialect{
+	CreateAction: "index",
+	IndexAction:  "index",
+	UpdateAction: "update",
+	DeleteAction: "delete",
+	DocumentType: true,
+	Upsert:       bulk.DocAsUpsert,
+	Params: bulk.Params{
+		Version:         "version",
+		VersionType:     "version_type",
+		RetryOnConflict: "retry_on_conflict",
+	},
+	RetryOnConflict: 3,
 }
@@ -14,13 +14,28 @@
 
 package v6
 
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+)
+
 //go:generate moq -out config_moq_test.go . config
 type config interface {
-	GetHost() string
+	GetHosts() []string
+	GetSniffOnStart() bool
+	GetSniffOnFailure() bool
 	GetUsername() string
 	GetPassword() string
 	GetCloudID() string
 	GetAPIKey() string
 	GetIndex() string
+	GetCompression() string
+	GetTLSConfig() *tls.Config
+	GetProxy() internal.ProxyFunc
+	GetHeader() http.Header
+	GetSigner() *sigv4.Signer
 	GetType() string
 }
@@ -14,9 +14,19 @@
 
 package v7
 
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+)
+
 //go:generate moq -out config_moq_test.go . config
 type config interface {
-	GetHost() string
+	GetHosts() []string
+	GetSniffOnStart() bool
+	GetSniffOnFailure() bool
 	GetUsername() string
 	GetPassword() string
 	GetCloudID() string
@@ -24,4 +34,9 @@ type config interface {
 	GetServiceToken() string
 	GetCertificateFingerprint() string
 	GetIndex() string
+	GetCompression() string
+	GetTLSConfig() *tls.Config
+	GetProxy() internal.ProxyFunc
+	GetHeader() http.Header
+	GetSigner() *sigv4.Signer
 }
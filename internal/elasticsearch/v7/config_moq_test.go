@@ -4,6 +4,10 @@
 package v7
 
 import (
+	"crypto/tls"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
+	"net/http"
+	"net/url"
 	"sync"
 )
 
@@ -26,8 +30,14 @@ var _ config = &configMock{}
 // 			GetCloudIDFunc: func() string {
 // 				panic("mock out the GetCloudID method")
 // 			},
-// 			GetHostFunc: func() string {
-// 				panic("mock out the GetHost method")
+// 			GetCompressionFunc: func() string {
+// 				panic("mock out the GetCompression method")
+// 			},
+// 			GetHeaderFunc: func() http.Header {
+// 				panic("mock out the GetHeader method")
+// 			},
+// 			GetHostsFunc: func() []string {
+// 				panic("mock out the GetHosts method")
 // 			},
 // 			GetIndexFunc: func() string {
 // 				panic("mock out the GetIndex method")
@@ -35,9 +45,24 @@ var _ config = &configMock{}
 // 			GetPasswordFunc: func() string {
 // 				panic("mock out the GetPassword method")
 // 			},
+// 			GetProxyFunc: func() func(*http.Request) (*url.URL, error) {
+// 				panic("mock out the GetProxy method")
+// 			},
 // 			GetServiceTokenFunc: func() string {
 // 				panic("mock out the GetServiceToken method")
 // 			},
+// 			GetSignerFunc: func() *sigv4.Signer {
+// 				panic("mock out the GetSigner method")
+// 			},
+// 			GetSniffOnFailureFunc: func() bool {
+// 				panic("mock out the GetSniffOnFailure method")
+// 			},
+// 			GetSniffOnStartFunc: func() bool {
+// 				panic("mock out the GetSniffOnStart method")
+// 			},
+// 			GetTLSConfigFunc: func() *tls.Config {
+// 				panic("mock out the GetTLSConfig method")
+// 			},
 // 			GetUsernameFunc: func() string {
 // 				panic("mock out the GetUsername method")
 // 			},
@@ -57,8 +82,14 @@ type configMock struct {
 	// GetCloudIDFunc mocks the GetCloudID method.
 	GetCloudIDFunc func() string
 
-	// GetHostFunc mocks the GetHost method.
-	GetHostFunc func() string
+	// GetCompressionFunc mocks the GetCompression method.
+	GetCompressionFunc func() string
+
+	// GetHeaderFunc mocks the GetHeader method.
+	GetHeaderFunc func() http.Header
+
+	// GetHostsFunc mocks the GetHosts method.
+	GetHostsFunc func() []string
 
 	// GetIndexFunc mocks the GetIndex method.
 	GetIndexFunc func() string
@@ -66,9 +97,24 @@ type configMock struct {
 	// GetPasswordFunc mocks the GetPassword method.
 	GetPasswordFunc func() string
 
+	// GetProxyFunc mocks the GetProxy method.
+	GetProxyFunc func() func(*http.Request) (*url.URL, error)
+
 	// GetServiceTokenFunc mocks the GetServiceToken method.
 	GetServiceTokenFunc func() string
 
+	// GetSignerFunc mocks the GetSigner method.
+	GetSignerFunc func() *sigv4.Signer
+
+	// GetSniffOnFailureFunc mocks the GetSniffOnFailure method.
+	GetSniffOnFailureFunc func() bool
+
+	// GetSniffOnStartFunc mocks the GetSniffOnStart method.
+	GetSniffOnStartFunc func() bool
+
+	// GetTLSConfigFunc mocks the GetTLSConfig method.
+	GetTLSConfigFunc func() *tls.Config
+
 	// GetUsernameFunc mocks the GetUsername method.
 	GetUsernameFunc func() string
 
@@ -83,8 +129,14 @@ type configMock struct {
 		// GetCloudID holds details about calls to the GetCloudID method.
 		GetCloudID []struct {
 		}
-		// GetHost holds details about calls to the GetHost method.
-		GetHost []struct {
+		// GetCompression holds details about calls to the GetCompression method.
+		GetCompression []struct {
+		}
+		// GetHeader holds details about calls to the GetHeader method.
+		GetHeader []struct {
+		}
+		// GetHosts holds details about calls to the GetHosts method.
+		GetHosts []struct {
 		}
 		// GetIndex holds details about calls to the GetIndex method.
 		GetIndex []struct {
@@ -92,9 +144,24 @@ type configMock struct {
 		// GetPassword holds details about calls to the GetPassword method.
 		GetPassword []struct {
 		}
+		// GetProxy holds details about calls to the GetProxy method.
+		GetProxy []struct {
+		}
 		// GetServiceToken holds details about calls to the GetServiceToken method.
 		GetServiceToken []struct {
 		}
+		// GetSigner holds details about calls to the GetSigner method.
+		GetSigner []struct {
+		}
+		// GetSniffOnFailure holds details about calls to the GetSniffOnFailure method.
+		GetSniffOnFailure []struct {
+		}
+		// GetSniffOnStart holds details about calls to the GetSniffOnStart method.
+		GetSniffOnStart []struct {
+		}
+		// GetTLSConfig holds details about calls to the GetTLSConfig method.
+		GetTLSConfig []struct {
+		}
 		// GetUsername holds details about calls to the GetUsername method.
 		GetUsername []struct {
 		}
@@ -102,10 +169,17 @@ type configMock struct {
 	lockGetAPIKey                 sync.RWMutex
 	lockGetCertificateFingerprint sync.RWMutex
 	lockGetCloudID                sync.RWMutex
-	lockGetHost                   sync.RWMutex
+	lockGetCompression            sync.RWMutex
+	lockGetHeader                 sync.RWMutex
+	lockGetHosts                  sync.RWMutex
 	lockGetIndex                  sync.RWMutex
 	lockGetPassword               sync.RWMutex
+	lockGetProxy                  sync.RWMutex
 	lockGetServiceToken           sync.RWMutex
+	lockGetSigner                 sync.RWMutex
+	lockGetSniffOnFailure         sync.RWMutex
+	lockGetSniffOnStart           sync.RWMutex
+	lockGetTLSConfig              sync.RWMutex
 	lockGetUsername               sync.RWMutex
 }
 
@@ -187,29 +261,81 @@ func (mock *configMock) GetCloudIDCalls() []struct {
 	return calls
 }
 
-// GetHost calls GetHostFunc.
-func (mock *configMock) GetHost() string {
-	if mock.GetHostFunc == nil {
-		panic("configMock.GetHostFunc: method is nil but config.GetHost was just called")
+// GetCompression calls GetCompressionFunc.
+func (mock *configMock) GetCompression() string {
+	if mock.GetCompressionFunc == nil {
+		panic("configMock.GetCompressionFunc: method is nil but config.GetCompression was just called")
 	}
 	callInfo := struct {
 	}{}
-	mock.lockGetHost.Lock()
-	mock.calls.GetHost = append(mock.calls.GetHost, callInfo)
-	mock.lockGetHost.Unlock()
-	return mock.GetHostFunc()
+	mock.lockGetCompression.Lock()
+	mock.calls.GetCompression = append(mock.calls.GetCompression, callInfo)
+	mock.lockGetCompression.Unlock()
+	return mock.GetCompressionFunc()
 }
 
-// GetHostCalls gets all the calls that were made to GetHost.
+// GetCompressionCalls gets all the calls that were made to GetCompression.
 // Check the length with:
-//     len(mockedconfig.GetHostCalls())
-func (mock *configMock) GetHostCalls() []struct {
+//     len(mockedconfig.GetCompressionCalls())
+func (mock *configMock) GetCompressionCalls() []struct {
 } {
 	var calls []struct {
 	}
-	mock.lockGetHost.RLock()
-	calls = mock.calls.GetHost
-	mock.lockGetHost.RUnlock()
+	mock.lockGetCompression.RLock()
+	calls = mock.calls.GetCompression
+	mock.lockGetCompression.RUnlock()
+	return calls
+}
+
+// GetHeader calls GetHeaderFunc.
+func (mock *configMock) GetHeader() http.Header {
+	if mock.GetHeaderFunc == nil {
+		panic("configMock.GetHeaderFunc: method is nil but config.GetHeader was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetHeader.Lock()
+	mock.calls.GetHeader = append(mock.calls.GetHeader, callInfo)
+	mock.lockGetHeader.Unlock()
+	return mock.GetHeaderFunc()
+}
+
+// GetHeaderCalls gets all the calls that were made to GetHeader.
+// Check the length with:
+//     len(mockedconfig.GetHeaderCalls())
+func (mock *configMock) GetHeaderCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetHeader.RLock()
+	calls = mock.calls.GetHeader
+	mock.lockGetHeader.RUnlock()
+	return calls
+}
+
+// GetHosts calls GetHostsFunc.
+func (mock *configMock) GetHosts() []string {
+	if mock.GetHostsFunc == nil {
+		panic("configMock.GetHostsFunc: method is nil but config.GetHosts was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetHosts.Lock()
+	mock.calls.GetHosts = append(mock.calls.GetHosts, callInfo)
+	mock.lockGetHosts.Unlock()
+	return mock.GetHostsFunc()
+}
+
+// GetHostsCalls gets all the calls that were made to GetHosts.
+// Check the length with:
+//     len(mockedconfig.GetHostsCalls())
+func (mock *configMock) GetHostsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetHosts.RLock()
+	calls = mock.calls.GetHosts
+	mock.lockGetHosts.RUnlock()
 	return calls
 }
 
@@ -265,6 +391,32 @@ func (mock *configMock) GetPasswordCalls() []struct {
 	return calls
 }
 
+// GetProxy calls GetProxyFunc.
+func (mock *configMock) GetProxy() func(*http.Request) (*url.URL, error) {
+	if mock.GetProxyFunc == nil {
+		panic("configMock.GetProxyFunc: method is nil but config.GetProxy was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetProxy.Lock()
+	mock.calls.GetProxy = append(mock.calls.GetProxy, callInfo)
+	mock.lockGetProxy.Unlock()
+	return mock.GetProxyFunc()
+}
+
+// GetProxyCalls gets all the calls that were made to GetProxy.
+// Check the length with:
+//     len(mockedconfig.GetProxyCalls())
+func (mock *configMock) GetProxyCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetProxy.RLock()
+	calls = mock.calls.GetProxy
+	mock.lockGetProxy.RUnlock()
+	return calls
+}
+
 // GetServiceToken calls GetServiceTokenFunc.
 func (mock *configMock) GetServiceToken() string {
 	if mock.GetServiceTokenFunc == nil {
@@ -291,6 +443,110 @@ func (mock *configMock) GetServiceTokenCalls() []struct {
 	return calls
 }
 
+// GetSigner calls GetSignerFunc.
+func (mock *configMock) GetSigner() *sigv4.Signer {
+	if mock.GetSignerFunc == nil {
+		panic("configMock.GetSignerFunc: method is nil but config.GetSigner was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetSigner.Lock()
+	mock.calls.GetSigner = append(mock.calls.GetSigner, callInfo)
+	mock.lockGetSigner.Unlock()
+	return mock.GetSignerFunc()
+}
+
+// GetSignerCalls gets all the calls that were made to GetSigner.
+// Check the length with:
+//     len(mockedconfig.GetSignerCalls())
+func (mock *configMock) GetSignerCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetSigner.RLock()
+	calls = mock.calls.GetSigner
+	mock.lockGetSigner.RUnlock()
+	return calls
+}
+
+// GetSniffOnFailure calls GetSniffOnFailureFunc.
+func (mock *configMock) GetSniffOnFailure() bool {
+	if mock.GetSniffOnFailureFunc == nil {
+		panic("configMock.GetSniffOnFailureFunc: method is nil but config.GetSniffOnFailure was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetSniffOnFailure.Lock()
+	mock.calls.GetSniffOnFailure = append(mock.calls.GetSniffOnFailure, callInfo)
+	mock.lockGetSniffOnFailure.Unlock()
+	return mock.GetSniffOnFailureFunc()
+}
+
+// GetSniffOnFailureCalls gets all the calls that were made to GetSniffOnFailure.
+// Check the length with:
+//     len(mockedconfig.GetSniffOnFailureCalls())
+func (mock *configMock) GetSniffOnFailureCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetSniffOnFailure.RLock()
+	calls = mock.calls.GetSniffOnFailure
+	mock.lockGetSniffOnFailure.RUnlock()
+	return calls
+}
+
+// GetSniffOnStart calls GetSniffOnStartFunc.
+func (mock *configMock) GetSniffOnStart() bool {
+	if mock.GetSniffOnStartFunc == nil {
+		panic("configMock.GetSniffOnStartFunc: method is nil but config.GetSniffOnStart was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetSniffOnStart.Lock()
+	mock.calls.GetSniffOnStart = append(mock.calls.GetSniffOnStart, callInfo)
+	mock.lockGetSniffOnStart.Unlock()
+	return mock.GetSniffOnStartFunc()
+}
+
+// GetSniffOnStartCalls gets all the calls that were made to GetSniffOnStart.
+// Check the length with:
+//     len(mockedconfig.GetSniffOnStartCalls())
+func (mock *configMock) GetSniffOnStartCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetSniffOnStart.RLock()
+	calls = mock.calls.GetSniffOnStart
+	mock.lockGetSniffOnStart.RUnlock()
+	return calls
+}
+
+// GetTLSConfig calls GetTLSConfigFunc.
+func (mock *configMock) GetTLSConfig() *tls.Config {
+	if mock.GetTLSConfigFunc == nil {
+		panic("configMock.GetTLSConfigFunc: method is nil but config.GetTLSConfig was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetTLSConfig.Lock()
+	mock.calls.GetTLSConfig = append(mock.calls.GetTLSConfig, callInfo)
+	mock.lockGetTLSConfig.Unlock()
+	return mock.GetTLSConfigFunc()
+}
+
+// GetTLSConfigCalls gets all the calls that were made to GetTLSConfig.
+// Check the length with:
+//     len(mockedconfig.GetTLSConfigCalls())
+func (mock *configMock) GetTLSConfigCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetTLSConfig.RLock()
+	calls = mock.calls.GetTLSConfig
+	mock.lockGetTLSConfig.RUnlock()
+	return calls
+}
+
 // GetUsername calls GetUsernameFunc.
 func (mock *configMock) GetUsername() string {
 	if mock.GetUsernameFunc == nil {
@@ -20,9 +20,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/bulk"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/elasticsearch/response"
+	"github.com/miquido/conduit-connector-elasticsearch/internal/sigv4"
 )
 
 func NewClient(cfg interface{}) (*Client, error) {
@@ -31,28 +37,38 @@ func NewClient(cfg interface{}) (*Client, error) {
 		return nil, errors.New("provided config object is invalid")
 	}
 
+	transport := sigv4.Transport(
+		internal.NewTransport(configTyped.GetTLSConfig(), configTyped.GetProxy()),
+		configTyped.GetSigner(),
+	)
+
 	esClient, err := elasticsearch.NewClient(elasticsearch.Config{
-		Addresses:              []string{configTyped.GetHost()},
+		Addresses:              configTyped.GetHosts(),
 		Username:               configTyped.GetUsername(),
 		Password:               configTyped.GetPassword(),
 		CloudID:                configTyped.GetCloudID(),
 		APIKey:                 configTyped.GetAPIKey(),
 		ServiceToken:           configTyped.GetServiceToken(),
 		CertificateFingerprint: configTyped.GetCertificateFingerprint(),
+		DiscoverNodesOnStart:   configTyped.GetSniffOnStart(),
+		Header:                 configTyped.GetHeader(),
+		Transport:              transport,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &Client{
-		es:  esClient,
-		cfg: configTyped,
+		es:        esClient,
+		transport: transport,
+		cfg:       configTyped,
 	}, nil
 }
 
 type Client struct {
-	es  *elasticsearch.Client
-	cfg config
+	es        *elasticsearch.Client
+	transport http.RoundTripper
+	cfg       config
 }
 
 // GetClient returns Elasticsearch v8 client.
@@ -73,91 +89,265 @@ func (c *Client) Ping(ctx context.Context) error {
 }
 
 func (c *Client) Bulk(ctx context.Context, reader io.Reader) (io.ReadCloser, error) {
-	result, err := c.es.Bulk(reader, c.es.Bulk.WithContext(ctx))
-	if err != nil {
-		return nil, err
+	options := []func(*esapi.BulkRequest){
+		c.es.Bulk.WithContext(ctx),
 	}
-	if result.IsError() {
-		bodyContents, err := io.ReadAll(result.Body)
+
+	if c.cfg.GetCompression() == internal.CompressionGzip {
+		compressed, err := internal.CompressRequestBody(reader)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read the result: %w", err)
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
 		}
 
-		if err := result.Body.Close(); err != nil {
-			return nil, fmt.Errorf("failed to read the result: %w", err)
-		}
+		reader = compressed
+		options = append(options, c.es.Bulk.WithHeader(internal.CompressionHeaders))
+	}
 
-		var errorDetails ErrorResponse
-		if err := json.Unmarshal(bodyContents, &errorDetails); err != nil {
-			return nil, errors.New(result.Status())
-		}
+	if opaqueID := internal.OpaqueID(ctx); opaqueID != "" {
+		options = append(options, c.es.Bulk.WithOpaqueID(opaqueID))
+	}
+
+	result, err := c.es.Bulk(reader, options...)
+	if err != nil {
+		c.sniffOnFailure(ctx)
+
+		return nil, &internal.TransportError{Err: err}
+	}
 
-		return nil, fmt.Errorf("[%s] %s", errorDetails.Error.Type, errorDetails.Error.Reason)
+	if result.Body, err = internal.DecompressResponseBody(result.Header, result.Body); err != nil {
+		return nil, &internal.TransportError{Err: err}
+	}
+
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
 	}
 
 	return result.Body, nil
 }
 
-func (c *Client) PrepareCreateOperation(item sdk.Record) (interface{}, interface{}, error) {
-	// Prepare metadata
-	metadata := bulkRequestActionAndMetadata{
-		Create: &bulkRequestCreateAction{
-			Index: c.cfg.GetIndex(),
-		},
+// sniffOnFailure refreshes the list of nodes when enabled, after the request could not reach any of them.
+func (c *Client) sniffOnFailure(ctx context.Context) {
+	if !c.cfg.GetSniffOnFailure() {
+		return
 	}
 
-	// Prepare payload
-	payload, err := preparePayload(&item)
+	if err := c.es.DiscoverNodes(); err != nil {
+		sdk.Logger(ctx).Warn().Err(err).Msg("failed to discover nodes")
+	}
+}
+
+func (c *Client) IndexExists(ctx context.Context) (bool, error) {
+	result, err := c.es.Indices.Exists([]string{c.cfg.GetIndex()}, c.es.Indices.Exists.WithContext(ctx))
 	if err != nil {
-		return nil, nil, err
+		return false, &internal.TransportError{Err: err}
 	}
 
-	return metadata, bulkRequestCreateSource(payload), nil
+	switch result.StatusCode {
+	case http.StatusOK:
+		return true, result.Body.Close()
+
+	case http.StatusNotFound:
+		return false, result.Body.Close()
+
+	default:
+		return false, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
 }
 
-func (c *Client) PrepareUpsertOperation(key string, item sdk.Record) (interface{}, interface{}, error) {
-	// Prepare metadata
-	metadata := bulkRequestActionAndMetadata{
-		Update: &bulkRequestUpdateAction{
-			ID:              key,
-			Index:           c.cfg.GetIndex(),
-			RetryOnConflict: 3,
-		},
+func (c *Client) CreateIndex(ctx context.Context, body io.Reader) error {
+	result, err := c.es.Indices.Create(
+		c.cfg.GetIndex(),
+		c.es.Indices.Create.WithContext(ctx),
+		c.es.Indices.Create.WithBody(body),
+	)
+	if err != nil {
+		return &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return response.ParseError(result.StatusCode, result.Status(), result.Body)
 	}
 
-	// Prepare payload
-	var err error
+	return result.Body.Close()
+}
 
-	payload := bulkRequestOptionalSource{
-		Doc:         nil,
-		DocAsUpsert: true,
+func (c *Client) GetMapping(ctx context.Context) (map[string]interface{}, error) {
+	result, err := c.es.Indices.GetMapping(
+		c.es.Indices.GetMapping.WithContext(ctx),
+		c.es.Indices.GetMapping.WithIndex(c.cfg.GetIndex()),
+	)
+	if err != nil {
+		return nil, &internal.TransportError{Err: err}
 	}
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	defer result.Body.Close()
+
+	var mappings map[string]struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if err := json.NewDecoder(result.Body).Decode(&mappings); err != nil {
+		return nil, fmt.Errorf("failed to decode the mapping: %w", err)
+	}
+
+	// The response is keyed by the concrete index name, which differs from the configured one for aliases
+	for _, index := range mappings {
+		return index.Mappings, nil
+	}
+
+	return nil, fmt.Errorf("mapping of %q index not found", c.cfg.GetIndex())
+}
 
-	payload.Doc, err = preparePayload(&item)
+func (c *Client) GetIndexTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	result, err := c.es.Indices.GetIndexTemplate(
+		c.es.Indices.GetIndexTemplate.WithContext(ctx),
+		c.es.Indices.GetIndexTemplate.WithName(name),
+	)
 	if err != nil {
-		return nil, nil, err
+		return nil, &internal.TransportError{Err: err}
+	}
+	if result.StatusCode == http.StatusNotFound {
+		return nil, result.Body.Close()
+	}
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
 	}
 
-	return metadata, payload, nil
+	defer result.Body.Close()
+
+	var templates struct {
+		IndexTemplates []struct {
+			Name          string                 `json:"name"`
+			IndexTemplate map[string]interface{} `json:"index_template"`
+		} `json:"index_templates"`
+	}
+	if err := json.NewDecoder(result.Body).Decode(&templates); err != nil {
+		return nil, fmt.Errorf("failed to decode the index template: %w", err)
+	}
+
+	for _, template := range templates.IndexTemplates {
+		if template.Name == name {
+			return template.IndexTemplate, nil
+		}
+	}
+
+	return nil, nil
 }
 
-func (c *Client) PrepareDeleteOperation(key string) (interface{}, error) {
-	return bulkRequestActionAndMetadata{
-		Delete: &bulkRequestDeleteAction{
-			ID:    key,
-			Index: c.cfg.GetIndex(),
-		},
-	}, nil
+func (c *Client) PutIndexTemplate(ctx context.Context, name string, body io.Reader) error {
+	result, err := c.es.Indices.PutIndexTemplate(name, body, c.es.Indices.PutIndexTemplate.WithContext(ctx))
+	if err != nil {
+		return &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	return result.Body.Close()
 }
 
-// preparePayload encodes Record's payload as JSON.
-func preparePayload(item *sdk.Record) ([]byte, error) {
-	switch itemPayload := item.Payload.(type) {
-	case sdk.StructuredData:
-		return json.Marshal(itemPayload)
+func (c *Client) GetComponentTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	result, err := c.es.Cluster.GetComponentTemplate(
+		c.es.Cluster.GetComponentTemplate.WithContext(ctx),
+		c.es.Cluster.GetComponentTemplate.WithName(name),
+	)
+	if err != nil {
+		return nil, &internal.TransportError{Err: err}
+	}
+	if result.StatusCode == http.StatusNotFound {
+		return nil, result.Body.Close()
+	}
+	if result.IsError() {
+		return nil, response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
 
-	default:
-		// Nothing more can be done, we can trust the source to provide valid JSON
-		return itemPayload.Bytes(), nil
+	defer result.Body.Close()
+
+	var templates struct {
+		ComponentTemplates []struct {
+			Name              string                 `json:"name"`
+			ComponentTemplate map[string]interface{} `json:"component_template"`
+		} `json:"component_templates"`
+	}
+	if err := json.NewDecoder(result.Body).Decode(&templates); err != nil {
+		return nil, fmt.Errorf("failed to decode the component template: %w", err)
+	}
+
+	for _, template := range templates.ComponentTemplates {
+		if template.Name == name {
+			return template.ComponentTemplate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Client) PutComponentTemplate(ctx context.Context, name string, body io.Reader) error {
+	result, err := c.es.Cluster.PutComponentTemplate(name, body, c.es.Cluster.PutComponentTemplate.WithContext(ctx))
+	if err != nil {
+		return &internal.TransportError{Err: err}
 	}
+	if result.IsError() {
+		return response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	return result.Body.Close()
+}
+
+func (c *Client) PutLifecyclePolicy(ctx context.Context, name string, body io.Reader) error {
+	result, err := c.es.ILM.PutLifecycle(
+		name,
+		c.es.ILM.PutLifecycle.WithContext(ctx),
+		c.es.ILM.PutLifecycle.WithBody(body),
+	)
+	if err != nil {
+		return &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	return result.Body.Close()
+}
+
+func (c *Client) CreateRolloverIndex(ctx context.Context, body io.Reader) error {
+	result, err := c.es.Indices.Create(
+		c.cfg.GetIndex()+"-000001",
+		c.es.Indices.Create.WithContext(ctx),
+		c.es.Indices.Create.WithBody(body),
+	)
+	if err != nil {
+		return &internal.TransportError{Err: err}
+	}
+	if result.IsError() {
+		return response.ParseError(result.StatusCode, result.Status(), result.Body)
+	}
+
+	return result.Body.Close()
+}
+
+func (c *Client) CloseIdleConnections() {
+	internal.CloseIdleConnections(c.transport)
+}
+
+func (c *Client) PrepareCreateOperation(item sdk.Record) (interface{}, interface{}, error) {
+	return c.bulkEncoder().CreateOperation(item)
+}
+
+func (c *Client) PrepareUpsertOperation(key string, item sdk.Record) (interface{}, interface{}, error) {
+	return c.bulkEncoder().UpsertOperation(key, item)
+}
+
+func (c *Client) PrepareIndexOperation(key string, item sdk.Record, version uint64) (interface{}, interface{}, error) {
+	return c.bulkEncoder().IndexOperation(key, item, version)
+}
+
+func (c *Client) PrepareDeleteOperation(key string, version uint64) (interface{}, error) {
+	return c.bulkEncoder().DeleteOperation(key, version)
+}
+
+// bulkEncoder creates Bulk API operations encoder for the configured index.
+func (c *Client) bulkEncoder() bulk.Encoder {
+	return bulk.NewEncoder(bulkDialect, c.cfg.GetIndex(), "")
 }
@@ -0,0 +1,76 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// TransportError is returned when a request could not be sent to Elasticsearch or its response could not be read.
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// ResponseError is returned when Elasticsearch responds to the whole request with an error status.
+type ResponseError struct {
+	StatusCode int
+	Type       string
+	Reason     string
+}
+
+func (e *ResponseError) Error() string {
+	if e.Type == "" {
+		return e.Reason
+	}
+
+	return fmt.Sprintf("[%s] %s", e.Type, e.Reason)
+}
+
+// IsRetryable returns whether the request may succeed when sent again later.
+func (e *ResponseError) IsRetryable() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// IsRetryableError returns whether the failed request may succeed when sent again later.
+// Transport failures and overloaded or unavailable cluster responses are considered transient.
+func IsRetryableError(err error) bool {
+	var responseErr *ResponseError
+	if errors.As(err, &responseErr) {
+		return responseErr.IsRetryable()
+	}
+
+	var transportErr *TransportError
+
+	return errors.As(err, &transportErr)
+}
@@ -0,0 +1,177 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigv4
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials are AWS security credentials used to sign requests.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// Expires is the time the temporary credentials expire at, or zero time when they do not expire.
+	Expires time.Time
+}
+
+// CredentialsProvider retrieves credentials before each request is signed.
+type CredentialsProvider interface {
+	Retrieve(ctx context.Context) (Credentials, error)
+}
+
+// StaticCredentials provides credentials given in the configuration.
+type StaticCredentials Credentials
+
+func (c StaticCredentials) Retrieve(context.Context) (Credentials, error) {
+	return Credentials(c), nil
+}
+
+// EnvironmentCredentials provides credentials taken from standard AWS environment variables.
+type EnvironmentCredentials struct{}
+
+func (EnvironmentCredentials) Retrieve(context.Context) (Credentials, error) {
+	credentials := Credentials{
+		AccessKeyID:     firstEnv("AWS_ACCESS_KEY_ID", "AWS_ACCESS_KEY"),
+		SecretAccessKey: firstEnv("AWS_SECRET_ACCESS_KEY", "AWS_SECRET_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+
+	if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+		return Credentials{}, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables must be set")
+	}
+
+	return credentials, nil
+}
+
+func firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// webIdentityRefreshWindow is how long before their expiration the temporary credentials are refreshed.
+const webIdentityRefreshWindow = 5 * time.Minute
+
+// WebIdentityCredentials provides temporary credentials of the role assumed with the web identity token,
+// e.g. the one projected into Kubernetes pods. The token file is read again on each refresh, as it is rotated.
+// See: https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRoleWithWebIdentity.html
+type WebIdentityCredentials struct {
+	RoleARN     string
+	TokenFile   string
+	SessionName string
+	// Endpoint is the URL of AWS Security Token Service.
+	Endpoint string
+	Client   *http.Client
+
+	mu          sync.Mutex
+	credentials Credentials
+}
+
+func (c *WebIdentityCredentials) Retrieve(ctx context.Context) (Credentials, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.credentials.AccessKeyID != "" && time.Until(c.credentials.Expires) > webIdentityRefreshWindow {
+		return c.credentials, nil
+	}
+
+	credentials, err := c.assumeRole(ctx)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to assume role with web identity: %w", err)
+	}
+
+	c.credentials = credentials
+
+	return credentials, nil
+}
+
+func (c *WebIdentityCredentials) assumeRole(ctx context.Context) (Credentials, error) {
+	token, err := os.ReadFile(c.TokenFile)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	query := url.Values{
+		"Action":           []string{"AssumeRoleWithWebIdentity"},
+		"Version":          []string{"2011-06-15"},
+		"RoleArn":          []string{c.RoleARN},
+		"RoleSessionName":  []string{c.SessionName},
+		"WebIdentityToken": []string{strings.TrimSpace(string(token))},
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, strings.NewReader(query.Encode()))
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		var errorResponse struct {
+			Code    string `xml:"Error>Code"`
+			Message string `xml:"Error>Message"`
+		}
+		if err := xml.NewDecoder(response.Body).Decode(&errorResponse); err != nil || errorResponse.Code == "" {
+			return Credentials{}, fmt.Errorf("unexpected response: %s", response.Status)
+		}
+
+		return Credentials{}, fmt.Errorf("[%s] %s", errorResponse.Code, errorResponse.Message)
+	}
+
+	var result struct {
+		Credentials struct {
+			AccessKeyID     string    `xml:"AccessKeyId"`
+			SecretAccessKey string    `xml:"SecretAccessKey"`
+			SessionToken    string    `xml:"SessionToken"`
+			Expiration      time.Time `xml:"Expiration"`
+		} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+	}
+	if err := xml.NewDecoder(response.Body).Decode(&result); err != nil {
+		return Credentials{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return Credentials{
+		AccessKeyID:     result.Credentials.AccessKeyID,
+		SecretAccessKey: result.Credentials.SecretAccessKey,
+		SessionToken:    result.Credentials.SessionToken,
+		Expires:         result.Credentials.Expiration,
+	}, nil
+}
@@ -0,0 +1,131 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigv4
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironmentCredentials_Retrieve(t *testing.T) {
+	t.Run("Returns credentials from environment variables", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		t.Setenv("AWS_SESSION_TOKEN", "token")
+
+		credentials, err := EnvironmentCredentials{}.Retrieve(context.Background())
+
+		require.NoError(t, err)
+		require.Equal(t, Credentials{
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+			SessionToken:    "token",
+		}, credentials)
+	})
+
+	t.Run("Fails when environment variables are not set", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "")
+		t.Setenv("AWS_ACCESS_KEY", "")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+		t.Setenv("AWS_SECRET_KEY", "")
+
+		_, err := EnvironmentCredentials{}.Retrieve(context.Background())
+
+		require.EqualError(t, err, "AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables must be set")
+	})
+}
+
+func TestWebIdentityCredentials_Retrieve(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("web-identity-token\n"), 0o600))
+
+	t.Run("Assumes role and caches temporary credentials", func(t *testing.T) {
+		var requests int
+
+		expiration := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			if r.FormValue("Action") != "AssumeRoleWithWebIdentity" ||
+				r.FormValue("RoleArn") != "arn:aws:iam::123456789012:role/conduit" ||
+				r.FormValue("RoleSessionName") != "conduit" ||
+				r.FormValue("WebIdentityToken") != "web-identity-token" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleWithWebIdentityResult>
+    <Credentials>
+      <SessionToken>token</SessionToken>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <Expiration>` + expiration.Format(time.RFC3339) + `</Expiration>
+      <AccessKeyId>ASIAEXAMPLE</AccessKeyId>
+    </Credentials>
+  </AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`))
+		}))
+		defer server.Close()
+
+		provider := WebIdentityCredentials{
+			RoleARN:     "arn:aws:iam::123456789012:role/conduit",
+			TokenFile:   tokenFile,
+			SessionName: "conduit",
+			Endpoint:    server.URL,
+		}
+
+		for i := 0; i < 2; i++ {
+			credentials, err := provider.Retrieve(context.Background())
+
+			require.NoError(t, err)
+			require.Equal(t, Credentials{
+				AccessKeyID:     "ASIAEXAMPLE",
+				SecretAccessKey: "secret",
+				SessionToken:    "token",
+				Expires:         expiration,
+			}, credentials)
+		}
+
+		require.Equal(t, 1, requests)
+	})
+
+	t.Run("Fails when role could not be assumed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<ErrorResponse><Error><Type>Sender</Type><Code>AccessDenied</Code><Message>Not authorized to perform sts:AssumeRoleWithWebIdentity</Message></Error></ErrorResponse>`))
+		}))
+		defer server.Close()
+
+		provider := WebIdentityCredentials{
+			RoleARN:     "arn:aws:iam::123456789012:role/conduit",
+			TokenFile:   tokenFile,
+			SessionName: "conduit",
+			Endpoint:    server.URL,
+		}
+
+		_, err := provider.Retrieve(context.Background())
+
+		require.EqualError(t, err, "failed to assume role with web identity: [AccessDenied] Not authorized to perform sts:AssumeRoleWithWebIdentity")
+	})
+}
@@ -0,0 +1,275 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigv4
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	algorithm       = "AWS4-HMAC-SHA256"
+	timeFormat      = "20060102T150405Z"
+	shortTimeFormat = "20060102"
+)
+
+// Signer signs requests with AWS Signature Version 4.
+// See: https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html
+type Signer struct {
+	Region      string
+	Service     string
+	Credentials CredentialsProvider
+	// Now returns the time of signing, defaults to time.Now.
+	Now func() time.Time
+}
+
+// Sign adds the signature of the request and its body to the request headers.
+// The body has to be passed exactly as it is sent, i.e. after compression.
+func (s *Signer) Sign(req *http.Request, body []byte) error {
+	credentials, err := s.Credentials.Retrieve(req.Context())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", now().UTC().Format(timeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	} else {
+		req.Header.Del("X-Amz-Security-Token")
+	}
+
+	req.Header.Set("Authorization", s.authorization(req, signedHeaderNames(req), payloadHash, credentials))
+
+	return nil
+}
+
+// authorization returns the Authorization header of the request with X-Amz-Date header set.
+func (s *Signer) authorization(req *http.Request, signedHeaders []string, payloadHash string, credentials Credentials) string {
+	var (
+		requestTime  = req.Header.Get("X-Amz-Date")
+		date         = requestTime[:len(shortTimeFormat)]
+		scope        = strings.Join([]string{date, s.Region, s.Service, "aws4_request"}, "/")
+		canonicalReq = canonicalRequest(req, signedHeaders, payloadHash)
+		stringToSign = strings.Join([]string{algorithm, requestTime, scope, sha256Hex([]byte(canonicalReq))}, "\n")
+		signingKey   = hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), date)
+	)
+
+	for _, part := range []string{s.Region, s.Service, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+
+	return fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm,
+		credentials.AccessKeyID,
+		scope,
+		strings.Join(signedHeaders, ";"),
+		hex.EncodeToString(hmacSHA256(signingKey, stringToSign)),
+	)
+}
+
+// signedHeaderNames returns sorted lowercase names of signed headers.
+// Headers which may be changed on the way, e.g. by proxies, are not signed.
+func signedHeaderNames(req *http.Request) []string {
+	names := []string{"host"}
+
+	for name := range req.Header {
+		name = strings.ToLower(name)
+
+		if name == "content-type" || name == "content-encoding" || strings.HasPrefix(name, "x-amz-") {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func canonicalRequest(req *http.Request, signedHeaders []string, payloadHash string) string {
+	var headers strings.Builder
+
+	for _, name := range signedHeaders {
+		var values []string
+
+		if name == "host" {
+			values = []string{requestHost(req)}
+		} else {
+			values = req.Header.Values(name)
+		}
+
+		for n, value := range values {
+			values[n] = strings.Join(strings.Fields(value), " ")
+		}
+
+		headers.WriteString(name + ":" + strings.Join(values, ",") + "\n")
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		canonicalPath(req.URL),
+		canonicalQuery(req.URL),
+		headers.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+}
+
+// requestHost returns the host sent in the Host header.
+func requestHost(req *http.Request) string {
+	if req.Host != "" {
+		return req.Host
+	}
+
+	return req.URL.Host
+}
+
+// canonicalPath returns URI-encoded path, encoded twice as expected by all services except S3.
+func canonicalPath(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+
+	return uriEncode(path, false)
+}
+
+// canonicalQuery returns query parameters sorted by encoded key, then by encoded value.
+// Pairs are sorted before they are joined, as "=" sorts after some characters allowed in keys.
+func canonicalQuery(u *url.URL) string {
+	query := u.Query()
+	pairs := make([][2]string, 0, len(query))
+
+	for key, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, [2]string{uriEncode(key, true), uriEncode(value, true)})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+
+		return pairs[i][1] < pairs[j][1]
+	})
+
+	encoded := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		encoded = append(encoded, pair[0]+"="+pair[1])
+	}
+
+	return strings.Join(encoded, "&")
+}
+
+// uriEncode encodes all characters except the unreserved ones, and slashes unless encodeSlash is set.
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~',
+			b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+
+	return encoded.String()
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// Transport returns the transport signing every request before it is sent with the given transport.
+// When the signer is nil, the transport is returned unchanged.
+func Transport(transport http.RoundTripper, signer *Signer) http.RoundTripper {
+	if signer == nil {
+		return transport
+	}
+
+	return &signingTransport{
+		transport: transport,
+		signer:    signer,
+	}
+}
+
+type signingTransport struct {
+	transport http.RoundTripper
+	signer    *Signer
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	} else {
+		req = req.Clone(req.Context())
+	}
+
+	if err := t.signer.Sign(req, body); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	return t.transport.RoundTrip(req)
+}
+
+func (t *signingTransport) CloseIdleConnections() {
+	if closer, ok := t.transport.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
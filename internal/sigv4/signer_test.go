@@ -0,0 +1,221 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigv4
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miquido/conduit-connector-elasticsearch/internal"
+	"github.com/stretchr/testify/require"
+)
+
+var testCredentials = StaticCredentials{
+	AccessKeyID:     "AKIDEXAMPLE",
+	SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+}
+
+func TestSigner_Authorization(t *testing.T) {
+	signer := Signer{
+		Region:      "us-east-1",
+		Service:     "service",
+		Credentials: testCredentials,
+	}
+
+	// Requests and signatures come from AWS Signature Version 4 test suite
+	for _, tt := range []struct {
+		name          string
+		url           string
+		authorization string
+	}{
+		{
+			name:          "get-vanilla",
+			url:           "https://example.amazonaws.com/",
+			authorization: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:          "get-vanilla-query-order-key-case",
+			url:           "https://example.amazonaws.com/?Param2=value2&Param1=value1",
+			authorization: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req.Header = http.Header{}
+			req.Header.Set("X-Amz-Date", "20150830T123600Z")
+
+			credentials, err := signer.Credentials.Retrieve(context.Background())
+			require.NoError(t, err)
+
+			require.Equal(t, tt.authorization, signer.authorization(req, signedHeaderNames(req), sha256Hex(nil), credentials))
+		})
+	}
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "sorts by key before value",
+			query: "a-b=1&a=2",
+			want:  "a=2&a-b=1",
+		},
+		{
+			name:  "sorts values of the same key",
+			query: "Param1=value2&Param1=Value1",
+			want:  "Param1=Value1&Param1=value2",
+		},
+		{
+			name:  "sorts by encoded key",
+			query: "a%20b=1&a=2&a_b=3",
+			want:  "a=2&a%20b=1&a_b=3",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse("https://example.amazonaws.com/?" + tt.query)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.want, canonicalQuery(u))
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	signer := &Signer{
+		Region:      "eu-west-1",
+		Service:     "es",
+		Credentials: testCredentials,
+	}
+
+	authorizationPattern := regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/\d{8}/eu-west-1/es/aws4_request, SignedHeaders=([a-z0-9;-]+), Signature=[0-9a-f]{64}$`)
+
+	// The stub verifies signatures the way AWS does, using the body as it was received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		matches := authorizationPattern.FindStringSubmatch(r.Header.Get("Authorization"))
+		if matches == nil || r.Header.Get("X-Amz-Content-Sha256") != sha256Hex(body) {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		credentials, _ := testCredentials.Retrieve(r.Context())
+		if signer.authorization(r, strings.Split(matches[1], ";"), sha256Hex(body), credentials) != r.Header.Get("Authorization") {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := http.Client{
+		Transport: Transport(http.DefaultTransport, signer),
+	}
+
+	t.Run("Signs request without body", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/users/_mapping?pretty=true", nil)
+		require.NoError(t, err)
+
+		response, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+		require.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Signs compressed request body", func(t *testing.T) {
+		body, err := internal.CompressRequestBody(strings.NewReader(`{"index":{"_index":"users"}}` + "\n" + `{"name":"John"}` + "\n"))
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/_bulk", body)
+		require.NoError(t, err)
+
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		req.Header.Set("Content-Encoding", "gzip")
+
+		response, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+		require.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("Request signed with other credentials is rejected", func(t *testing.T) {
+		client := http.Client{
+			Transport: Transport(http.DefaultTransport, &Signer{
+				Region:  "eu-west-1",
+				Service: "es",
+				Credentials: StaticCredentials{
+					AccessKeyID:     "AKIDEXAMPLE",
+					SecretAccessKey: "other",
+				},
+			}),
+		}
+
+		response, err := client.Post(server.URL+"/_bulk", "application/x-ndjson", strings.NewReader("{}\n"))
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+		require.Equal(t, http.StatusForbidden, response.StatusCode)
+	})
+
+	t.Run("Sends session token of temporary credentials", func(t *testing.T) {
+		var sessionToken string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sessionToken = r.Header.Get("X-Amz-Security-Token")
+		}))
+		defer server.Close()
+
+		client := http.Client{
+			Transport: Transport(http.DefaultTransport, &Signer{
+				Region:  "eu-west-1",
+				Service: "es",
+				Credentials: StaticCredentials{
+					AccessKeyID:     "AKIDEXAMPLE",
+					SecretAccessKey: "secret",
+					SessionToken:    "token",
+				},
+				Now: func() time.Time {
+					return time.Date(2022, 10, 5, 12, 0, 0, 0, time.UTC)
+				},
+			}),
+		}
+
+		response, err := client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+		require.Equal(t, "token", sessionToken)
+	})
+
+	t.Run("Returns transport unchanged when signer is not provided", func(t *testing.T) {
+		require.Same(t, http.DefaultTransport, Transport(http.DefaultTransport, nil))
+	})
+}
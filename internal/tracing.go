@@ -0,0 +1,35 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OpaqueIDHeader is the header which Elasticsearch copies into slow logs and tasks to identify the origin of requests.
+const OpaqueIDHeader = "X-Opaque-Id"
+
+// OpaqueID returns the ID of the trace recorded in the context, or an empty string when the context is not traced.
+// Clients send it with Bulk API requests, so the trace can be matched with Elasticsearch slow logs and tasks.
+func OpaqueID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
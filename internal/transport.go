@@ -0,0 +1,79 @@
+// Copyright © 2022 Meroxa, Inc. and Miquido
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+)
+
+// ProxyFunc returns the URL of the proxy to use for the request, or nil when the request should be sent directly.
+type ProxyFunc = func(*http.Request) (*url.URL, error)
+
+// NewTransport returns the HTTP transport of Elasticsearch clients.
+// It is based on the default transport, with its TLS configuration and proxy replaced when they are provided.
+func NewTransport(tlsConfig *tls.Config, proxy ProxyFunc) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig.Clone()
+	}
+
+	if proxy != nil {
+		transport.Proxy = proxy
+	}
+
+	return transport
+}
+
+// CloseIdleConnections closes connections of the transport which are not in use, when the transport supports it.
+func CloseIdleConnections(transport http.RoundTripper) {
+	if closer, ok := transport.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// WithHeader returns the transport setting static headers on every request,
+// for clients which cannot be configured with global headers.
+func WithHeader(transport http.RoundTripper, header http.Header) http.RoundTripper {
+	if len(header) == 0 {
+		return transport
+	}
+
+	return &headerTransport{
+		transport: transport,
+		header:    header,
+	}
+}
+
+type headerTransport struct {
+	transport http.RoundTripper
+	header    http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	for name, values := range t.header {
+		req.Header[name] = values
+	}
+
+	return t.transport.RoundTrip(req)
+}
+
+func (t *headerTransport) CloseIdleConnections() {
+	CloseIdleConnections(t.transport)
+}
@@ -34,17 +34,21 @@ func Specification() sdk.Specification {
 				Default:  "",
 				Required: true,
 				Description: fmt.Sprintf(
-					"The version of the Elasticsearch service. One of: %s, %s, %s, %s",
+					"The version of the Elasticsearch service. One of: %s, %s, %s, %s, or %s, %s for OpenSearch, or %s for any version speaking the stable REST API, or %s to detect it on startup",
 					elasticsearch.Version5,
 					elasticsearch.Version6,
 					elasticsearch.Version7,
 					elasticsearch.Version8,
+					elasticsearch.VersionOpenSearch1,
+					elasticsearch.VersionOpenSearch2,
+					elasticsearch.VersionGeneric,
+					elasticsearch.VersionAuto,
 				),
 			},
 			destination.ConfigKeyHost: {
 				Default:     "",
 				Required:    true,
-				Description: "The Elasticsearch host and port (e.g.: http://127.0.0.1:9200).",
+				Description: "The Elasticsearch host and port (e.g.: http://127.0.0.1:9200). Multiple hosts can be provided as comma separated list.",
 			},
 			destination.ConfigKeyUsername: {
 				Default:     "",
@@ -59,17 +63,22 @@ func Specification() sdk.Specification {
 			destination.ConfigKeyCloudID: {
 				Default:     "",
 				Required:    false,
-				Description: "Endpoint for the Elastic Service (https://elastic.co/cloud).",
+				Description: "Endpoint for the Elastic Service (https://elastic.co/cloud). Not supported by OpenSearch.",
 			},
 			destination.ConfigKeyAPIKey: {
 				Default:     "",
 				Required:    false,
-				Description: "Base64-encoded token for authorization; if set, overrides username/password and service token.",
+				Description: "Base64-encoded token for authorization; if set, overrides username/password and service token. Not supported by OpenSearch.",
 			},
 			destination.ConfigKeyServiceToken: {
 				Default:     "",
 				Required:    false,
-				Description: "Service token for authorization; if set, overrides username/password.",
+				Description: "Service token for authorization; if set, overrides username/password. Not supported by OpenSearch.",
+			},
+			destination.ConfigKeyBearerToken: {
+				Default:     "",
+				Required:    false,
+				Description: "JSON Web Token for the JWT and OpenID Connect authentication of the OpenSearch security plugin; if set, overrides username/password. Supported only by OpenSearch.",
 			},
 			destination.ConfigKeyCertificateFingerprint: {
 				Default:     "",
@@ -91,11 +100,286 @@ func Specification() sdk.Specification {
 				Required:    true,
 				Description: "The number of items stored in bulk in the index. The minimum value is `1`, maximum value is `10 000`.",
 			},
+			destination.ConfigKeyBulkMaxBytes: {
+				Default:     "0",
+				Required:    false,
+				Description: "The maximum size in bytes of the encoded bulk request. A flush is triggered by whichever of `bulkSize` and `bulkMaxBytes` is reached first. The value `0` disables the limit.",
+			},
+			destination.ConfigKeyBulkWorkers: {
+				Default:     "1",
+				Required:    false,
+				Description: "The number of concurrent bulk requests. Operations on the same Document are always sent in order by the same worker. The minimum value is `1`, maximum value is `255`.",
+			},
 			destination.ConfigKeyRetries: {
 				Default:     "0",
 				Required:    false,
 				Description: "The maximum number of retries of failed operations. The minimum value is `0` which disabled retry logic. The maximum value is `255.",
 			},
+			destination.ConfigKeyRetryInitialDelay: {
+				Default:     "100ms",
+				Required:    false,
+				Description: "The delay before the first retry of failed operations. The value `0` disables delays between retries.",
+			},
+			destination.ConfigKeyRetryMaxDelay: {
+				Default:     "30s",
+				Required:    false,
+				Description: "The maximum delay between retries of failed operations.",
+			},
+			destination.ConfigKeyRetryMultiplier: {
+				Default:     "2",
+				Required:    false,
+				Description: "The factor by which the delay grows with each retry. The minimum value is `1`.",
+			},
+			destination.ConfigKeyRetryJitter: {
+				Default:     "0.2",
+				Required:    false,
+				Description: "The fraction by which each delay is randomly reduced. The value must be between `0` and `1`.",
+			},
+			destination.ConfigKeyDrainTimeout: {
+				Default:     "30s",
+				Required:    false,
+				Description: "The maximum time to flush buffered operations on teardown, greater than `0`. Records which could not be written in time are nacked.",
+			},
+			destination.ConfigKeyDeadLetterIndex: {
+				Default:     "",
+				Required:    false,
+				Description: "The name of the index to store Documents which failed permanently. When set, such Records are acknowledged as handled.",
+			},
+			destination.ConfigKeyFailurePolicies: {
+				Default:     "delete:404=success,version_conflict_engine_exception=skip,mapper_parsing_exception=deadLetter,429=retry",
+				Required:    false,
+				Description: "Comma separated list of `[action:]status|errorType=policy` entries defining how failed items are handled. Supported policies: `success`, `skip`, `retry`, `deadLetter`, `fail`. Entries are merged with the defaults.",
+			},
+			destination.ConfigKeyIndexSettings: {
+				Default:     "",
+				Required:    false,
+				Description: "JSON body with settings and mappings used to create the index when it does not exist. The mapping of an existing index is checked against it.",
+			},
+			destination.ConfigKeyIndexSettingsFile: {
+				Default:     "",
+				Required:    false,
+				Description: "The path to a file with the JSON body described in `indexSettings`. Must not be set together with `indexSettings`.",
+			},
+			destination.ConfigKeyIndexTemplate: {
+				Default:     "",
+				Required:    false,
+				Description: "JSON body of the index template to install. Versions 5 and 6 use legacy index templates, newer versions use composable index templates.",
+			},
+			destination.ConfigKeyIndexTemplateFile: {
+				Default:     "",
+				Required:    false,
+				Description: "The path to a file with the JSON body described in `indexTemplate`. Must not be set together with `indexTemplate`.",
+			},
+			destination.ConfigKeyIndexTemplateName: {
+				Default:     "",
+				Required:    false,
+				Description: "The name of the index template. Defaults to the name of the index.",
+			},
+			destination.ConfigKeyComponentTemplates: {
+				Default:     "",
+				Required:    false,
+				Description: "JSON object mapping component template names to their bodies. Supported by versions 7.8 and newer.",
+			},
+			destination.ConfigKeyComponentTemplatesFile: {
+				Default:     "",
+				Required:    false,
+				Description: "The path to a file with the JSON object described in `componentTemplates`. Must not be set together with `componentTemplates`.",
+			},
+			destination.ConfigKeyTemplateVersion: {
+				Default:     "1",
+				Required:    false,
+				Description: "The version of configured templates. Installed templates are replaced only when their version is lower.",
+			},
+			destination.ConfigKeyMappingInferenceSamples: {
+				Default:     "0",
+				Required:    false,
+				Description: "The number of structured Records sampled to infer the mapping of the index, which is created before the first flush. The value `0` disables inference.",
+			},
+			destination.ConfigKeyFieldsNesting: {
+				Default:     "",
+				Required:    false,
+				Description: "`flatten` replaces nested objects of the payload with dotted keys, `expand` replaces dotted keys with nested objects.",
+			},
+			destination.ConfigKeyFieldsInclude: {
+				Default:     "",
+				Required:    false,
+				Description: "Comma separated list of payload fields to keep, all other fields are removed.",
+			},
+			destination.ConfigKeyFieldsExclude: {
+				Default:     "",
+				Required:    false,
+				Description: "Comma separated list of payload fields to remove.",
+			},
+			destination.ConfigKeyFieldsRename: {
+				Default:     "",
+				Required:    false,
+				Description: "Comma separated list of `from:to` entries renaming payload fields.",
+			},
+			destination.ConfigKeyFieldsConstant: {
+				Default:     "",
+				Required:    false,
+				Description: "Comma separated list of `field:value` entries setting payload fields to constant values.",
+			},
+			destination.ConfigKeyILMPolicy: {
+				Default:     "",
+				Required:    false,
+				Description: "The name of the index lifecycle policy. When set, the index is used as the write alias of the rollover series. Supported by versions 6.6 and newer, not supported by OpenSearch.",
+			},
+			destination.ConfigKeyILMRolloverMaxAge: {
+				Default:     "",
+				Required:    false,
+				Description: "The maximum age of the write index before it is rolled over, e.g. `1d`. Defaults to `30d` when no rollover condition is set.",
+			},
+			destination.ConfigKeyILMRolloverMaxSize: {
+				Default:     "",
+				Required:    false,
+				Description: "The maximum primary shards size of the write index before it is rolled over, e.g. `50gb`. Defaults to `50gb` when no rollover condition is set.",
+			},
+			destination.ConfigKeyILMRolloverMaxDocs: {
+				Default:     "",
+				Required:    false,
+				Description: "The maximum number of Documents in the write index before it is rolled over.",
+			},
+			destination.ConfigKeyILMWarmMinAge: {
+				Default:     "",
+				Required:    false,
+				Description: "The age after rollover at which the index enters the warm phase, e.g. `7d`.",
+			},
+			destination.ConfigKeyILMDeleteMinAge: {
+				Default:     "",
+				Required:    false,
+				Description: "The age after rollover at which the index is deleted, e.g. `30d`.",
+			},
+			destination.ConfigKeyDebeziumEnvelope: {
+				Default:     "false",
+				Required:    false,
+				Description: "Whether payloads in the Debezium change event format are unpacked. The row after the change is indexed for create, update and read operations, and the Document is deleted for delete operations.",
+			},
+			destination.ConfigKeyDebeziumVersioning: {
+				Default:     "false",
+				Required:    false,
+				Description: "Whether the `ts_ms` field of Debezium change events is used as the external version of Documents, so older events never overwrite newer ones. Requires `debeziumEnvelope`.",
+			},
+			destination.ConfigKeyContentHashID: {
+				Default:     "false",
+				Required:    false,
+				Description: "Whether Records without Key are indexed with the ID derived from the SHA-256 hash of their payload, so redelivered Records overwrite Documents instead of duplicating them.",
+			},
+			destination.ConfigKeyContentHashFields: {
+				Default:     "",
+				Required:    false,
+				Description: "Comma separated list of payload fields whose values are hashed instead of the whole payload. Requires `contentHashId`.",
+			},
+			destination.ConfigKeyCompression: {
+				Default:     "none",
+				Required:    false,
+				Description: "The compression of Bulk API requests and responses. One of: `none`, `gzip`.",
+			},
+			destination.ConfigKeySniffOnStart: {
+				Default:     "false",
+				Required:    false,
+				Description: "Whether the nodes of the cluster are discovered when the connector starts. Supported by versions 6 and newer.",
+			},
+			destination.ConfigKeySniffOnFailure: {
+				Default:     "false",
+				Required:    false,
+				Description: "Whether the nodes of the cluster are discovered again when a Bulk API request could not reach any node. Supported by versions 6 and newer.",
+			},
+			destination.ConfigKeyTLSCACertFile: {
+				Default:     "",
+				Required:    false,
+				Description: "The path to a PEM file with certificates of authorities trusted to sign the certificate of Elasticsearch, replacing the system ones.",
+			},
+			destination.ConfigKeyTLSClientCertFile: {
+				Default:     "",
+				Required:    false,
+				Description: "The path to a PEM file with the client certificate used for mutual TLS authentication. Requires `tlsClientKeyFile`.",
+			},
+			destination.ConfigKeyTLSClientKeyFile: {
+				Default:     "",
+				Required:    false,
+				Description: "The path to a PEM file with the private key of the client certificate. Requires `tlsClientCertFile`.",
+			},
+			destination.ConfigKeyTLSMinVersion: {
+				Default:     "1.2",
+				Required:    false,
+				Description: "The minimum accepted TLS version. One of: `1.0`, `1.1`, `1.2`, `1.3`.",
+			},
+			destination.ConfigKeyTLSInsecureSkipVerify: {
+				Default:     "false",
+				Required:    false,
+				Description: "Whether the certificate of Elasticsearch and its host name are accepted without verification. Meant for testing only.",
+			},
+			destination.ConfigKeyProxyURL: {
+				Default:     "",
+				Required:    false,
+				Description: "The URL of the HTTP(S) or SOCKS5 proxy of requests to Elasticsearch, e.g. `http://proxy.local:3128`. By default the proxy is taken from `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables.",
+			},
+			destination.ConfigKeyNoProxy: {
+				Default:     "",
+				Required:    false,
+				Description: "Comma separated list of hosts, domains (e.g. `.internal`) and IP ranges reached without the proxy. Requires `proxyUrl`.",
+			},
+			destination.ConfigKeyHeaders: {
+				Default:     "",
+				Required:    false,
+				Description: "Comma separated list of `name:value` entries with headers sent with every request. Header values are never logged.",
+			},
+			destination.ConfigKeyAWSSigV4: {
+				Default:     "false",
+				Required:    false,
+				Description: "Whether to sign requests with AWS Signature Version 4, e.g. for Amazon OpenSearch Service. Cannot be combined with `username`, `apiKey`, `serviceToken`, `bearerToken` and `certificateFingerprint`.",
+			},
+			destination.ConfigKeyAWSRegion: {
+				Default:     "",
+				Required:    false,
+				Description: "The AWS region of the domain, e.g. `eu-west-1`. Required when `awsSigV4` is enabled.",
+			},
+			destination.ConfigKeyAWSService: {
+				Default:     "es",
+				Required:    false,
+				Description: "The AWS service name used in the signature: `es` for managed domains or `aoss` for OpenSearch Serverless.",
+			},
+			destination.ConfigKeyAWSCredentials: {
+				Default:     "environment",
+				Required:    false,
+				Description: "The source of AWS credentials: `static`, `environment` or `webIdentity`.",
+			},
+			destination.ConfigKeyAWSAccessKeyID: {
+				Default:     "",
+				Required:    false,
+				Description: "The AWS access key ID. Required when `awsCredentials` is `static`.",
+			},
+			destination.ConfigKeyAWSSecretAccessKey: {
+				Default:     "",
+				Required:    false,
+				Description: "The AWS secret access key. Required when `awsCredentials` is `static`.",
+			},
+			destination.ConfigKeyAWSSessionToken: {
+				Default:     "",
+				Required:    false,
+				Description: "The optional AWS session token of temporary `static` credentials.",
+			},
+			destination.ConfigKeyAWSRoleARN: {
+				Default:     "",
+				Required:    false,
+				Description: "The ARN of the role assumed with web identity. Defaults to `AWS_ROLE_ARN` environment variable.",
+			},
+			destination.ConfigKeyAWSWebIdentityTokenFile: {
+				Default:     "",
+				Required:    false,
+				Description: "The path to the web identity token file. Defaults to `AWS_WEB_IDENTITY_TOKEN_FILE` environment variable.",
+			},
+			destination.ConfigKeyAWSRoleSessionName: {
+				Default:     "conduit-connector-elasticsearch",
+				Required:    false,
+				Description: "The session name used when assuming the role with web identity.",
+			},
+			destination.ConfigKeyMetricsAddress: {
+				Default:     "",
+				Required:    false,
+				Description: "The address of the local HTTP listener serving Prometheus metrics at the /metrics path, e.g. `127.0.0.1:9464`. Metrics are not served when empty.",
+			},
 		},
 		SourceParams: map[string]sdk.Parameter{
 			//